@@ -0,0 +1,60 @@
+// Package ecdh defines the interface shared by the curve specific packages in
+// this module, so that code can be written once and run with any of them.
+//
+// Each of ecdh25519, ecdh448 and ecdhp256 exports a Scheme value implementing
+// Scheme, and registers it when imported.
+package ecdh
+
+import (
+	"io"
+	"sort"
+	"sync"
+)
+
+var (
+	schemesMu sync.RWMutex
+	schemes   = make(map[string]Scheme)
+)
+
+// Scheme is an elliptic curve diffie-hellman key agreement scheme. Keys are
+// passed as raw bytes in the encoding of the implementing package.
+type Scheme interface {
+	// Name returns the name of the scheme, such as "x25519", for negotiating
+	// the scheme at runtime.
+	Name() string
+	// GenerateKeyPair generates a public/private key pair using entropy from rand.
+	// If rand is nil, crypto/rand.Reader will be used.
+	GenerateKeyPair(rand io.Reader) (publicKey, privateKey []byte, err error)
+	// SharedSecret generates a shared secret by using someone else's public key.
+	SharedSecret(privateKey, publicKey []byte) ([]byte, error)
+}
+
+// Register makes a scheme available by its name. It is called from the init
+// function of the package implementing the scheme. If the name is already
+// registered, Register panics.
+func Register(s Scheme) {
+	schemesMu.Lock()
+	defer schemesMu.Unlock()
+
+	name := s.Name()
+	if _, dup := schemes[name]; dup {
+		panic("ecdh: Register called twice for scheme " + name)
+	}
+
+	schemes[name] = s
+}
+
+// Schemes returns the sorted names of the registered schemes, that is of the
+// scheme packages compiled into the program.
+func Schemes() []string {
+	schemesMu.RLock()
+	defer schemesMu.RUnlock()
+
+	names := make([]string, 0, len(schemes))
+	for name := range schemes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
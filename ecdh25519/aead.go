@@ -0,0 +1,38 @@
+package ecdh25519
+
+import (
+	"crypto/cipher"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+var aeadInfo = []byte("ecdh25519 aead")
+
+func init() {
+	registerCapability("chacha20poly1305")
+}
+
+// NewAEAD performs the key agreement between privateKey and publicKey, derives
+// a 32-byte key from the shared secret with HKDF-SHA256 and returns a
+// ChaCha20-Poly1305 AEAD keyed with it. Both sides of the exchange get the same
+// cipher. It returns the same errors as GenerateSharedSecret.
+//
+// The key is the same every time for a given pair of keys, so callers must
+// never reuse a nonce under it; use random nonces or a message counter.
+func NewAEAD(privateKey PrivateKey, publicKey PublicKey) (cipher.AEAD, error) {
+	sharedSecret, err := GenerateSharedSecret(privateKey, publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	defer zeroize(sharedSecret)
+
+	key := make([]byte, chacha20poly1305.KeySize)
+	defer zeroize(key)
+
+	if err := hkdfExpand(key, sharedSecret, nil, aeadInfo); err != nil {
+		return nil, err
+	}
+
+	return chacha20poly1305.New(key)
+}
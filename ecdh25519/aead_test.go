@@ -0,0 +1,80 @@
+package ecdh25519_test
+
+import (
+	"crypto/rand"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestNewAEAD(t *testing.T) {
+	alicePublicKey, alicePrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bobPublicKey, bobPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	aliceAEAD, err := ecdh25519.NewAEAD(alicePrivateKey, bobPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bobAEAD, err := ecdh25519.NewAEAD(bobPrivateKey, alicePublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plaintext := []byte("hello")
+	nonce := make([]byte, aliceAEAD.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := bobAEAD.Open(nil, nonce, aliceAEAD.Seal(nil, nonce, plaintext, nil), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, plaintext) {
+		t.Errorf("Open() = %v, want %v", got, plaintext)
+	}
+}
+
+func TestNewAEAD_errors(t *testing.T) {
+	_, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		publicKey ecdh25519.PublicKey
+		wantErr   error
+	}{
+		{
+			name:      "with short public key",
+			publicKey: make([]byte, 16),
+			wantErr:   ecdh25519.ErrBadPublicKeyLength,
+		},
+		{
+			name:      "with low order public key",
+			publicKey: make([]byte, ecdh25519.PublicKeySize),
+			wantErr:   ecdh25519.ErrLowOrderPoint,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ecdh25519.NewAEAD(privateKey, tt.publicKey)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewAEAD() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
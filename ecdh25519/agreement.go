@@ -0,0 +1,46 @@
+package ecdh25519
+
+import "fmt"
+
+// Agreement is a local key pair prepared for repeated key agreement, as when a
+// server computes shared secrets with many peers using its static key. The
+// private key is validated and its public key derived once, when the
+// Agreement is created.
+type Agreement struct {
+	privateKey [PrivateKeySize]byte
+	publicKey  [PublicKeySize]byte
+}
+
+// NewAgreement returns an Agreement for privateKey. The key is copied, so the
+// caller may zeroize its own copy afterwards.
+func NewAgreement(privateKey PrivateKey) (*Agreement, error) {
+	if l := len(privateKey); l != PrivateKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	publicKey, err := privateKey.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Agreement{}
+	copy(a.privateKey[:], privateKey)
+	copy(a.publicKey[:], publicKey)
+
+	return a, nil
+}
+
+// PublicKey returns a copy of the local public key, to be sent to peers.
+func (a *Agreement) PublicKey() PublicKey {
+	return append(PublicKey(nil), a.publicKey[:]...)
+}
+
+// SharedSecret generates the shared secret between the local private key and
+// peer. It returns the same errors as GenerateSharedSecret.
+func (a *Agreement) SharedSecret(peer PublicKey) (SharedSecret, error) {
+	if l := len(peer); l != PublicKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	return scalarMultChecked(a.privateKey[:], peer)
+}
@@ -0,0 +1,62 @@
+package ecdh25519_test
+
+import (
+	"crypto/rand"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestAgreement_SharedSecret(t *testing.T) {
+	alicePublicKey, alicePrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bobPublicKey, bobPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := ecdh25519.NewAgreement(alicePrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The Agreement keeps its own copy of the key.
+	alicePrivateKey.Zeroize()
+
+	if got := a.PublicKey(); !reflect.DeepEqual(got, alicePublicKey) {
+		t.Errorf("Agreement.PublicKey() = %v, want %v", got, alicePublicKey)
+	}
+
+	got, err := a.SharedSecret(bobPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := ecdh25519.GenerateSharedSecret(bobPrivateKey, alicePublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Agreement.SharedSecret() = %v, want %v", got, want)
+	}
+
+	if _, err := a.SharedSecret(bobPublicKey[:16]); !errors.Is(err, ecdh25519.ErrBadPublicKeyLength) {
+		t.Errorf("Agreement.SharedSecret() error = %v, want %v", err, ecdh25519.ErrBadPublicKeyLength)
+	}
+
+	if _, err := a.SharedSecret(make([]byte, ecdh25519.PublicKeySize)); !errors.Is(err, ecdh25519.ErrLowOrderPoint) {
+		t.Errorf("Agreement.SharedSecret() error = %v, want %v", err, ecdh25519.ErrLowOrderPoint)
+	}
+}
+
+func TestNewAgreement(t *testing.T) {
+	if _, err := ecdh25519.NewAgreement(make([]byte, 16)); !errors.Is(err, ecdh25519.ErrBadPrivateKeyLength) {
+		t.Errorf("NewAgreement() error = %v, want %v", err, ecdh25519.ErrBadPrivateKeyLength)
+	}
+}
@@ -0,0 +1,95 @@
+package ecdh25519
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// concurrentBatchThreshold is the batch size from which GenerateSharedSecrets
+// spreads the work over several goroutines.
+const concurrentBatchThreshold = 64
+
+// generateSharedSecretsBounded is the batch worker of GenerateSharedSecrets.
+// Tests replace it to observe the secrets it computes.
+var generateSharedSecretsBounded = GenerateSharedSecretsBounded
+
+// GenerateSharedSecrets generates the shared secrets between privateKey and
+// each of publicKeys, for example to encrypt one message to many recipients
+// with a single ephemeral key. The secrets are returned in the order of
+// publicKeys. Large batches are computed concurrently.
+//
+// All keys are checked before any secret is computed. If a public key is
+// malformed, or any key agreement fails, no secrets are returned and the error
+// of the first failing key is annotated with its index. The secrets computed
+// for the other keys are zeroized before returning.
+func GenerateSharedSecrets(privateKey PrivateKey, publicKeys []PublicKey) ([]SharedSecret, error) {
+	if l := len(privateKey); l != PrivateKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	for i, publicKey := range publicKeys {
+		if l := len(publicKey); l != PublicKeySize {
+			return nil, fmt.Errorf("%w: %d: index %d", ErrBadPublicKeyLength, l, i)
+		}
+	}
+
+	maxConcurrency := 1
+	if len(publicKeys) >= concurrentBatchThreshold {
+		maxConcurrency = runtime.GOMAXPROCS(0)
+	}
+
+	sharedSecrets, errs := generateSharedSecretsBounded(privateKey, publicKeys, maxConcurrency)
+	for i, err := range errs {
+		if err != nil {
+			for _, sharedSecret := range sharedSecrets {
+				sharedSecret.Zeroize()
+			}
+
+			return nil, fmt.Errorf("%w: index %d", err, i)
+		}
+	}
+
+	return sharedSecrets, nil
+}
+
+// GenerateSharedSecretsBounded generates the shared secrets between privateKey
+// and each of publicKeys using at most maxConcurrency goroutines. Values of
+// maxConcurrency below 1 are treated as 1.
+//
+// Results are returned by index: for each public key, either its secret or its
+// error is set, so a malformed key does not abort the rest of the batch.
+func GenerateSharedSecretsBounded(privateKey PrivateKey, publicKeys []PublicKey, maxConcurrency int) ([]SharedSecret, []error) {
+	sharedSecrets := make([]SharedSecret, len(publicKeys))
+	errs := make([]error, len(publicKeys))
+
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+
+	if maxConcurrency > len(publicKeys) {
+		maxConcurrency = len(publicKeys)
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < maxConcurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				sharedSecrets[i], errs[i] = GenerateSharedSecret(privateKey, publicKeys[i])
+			}
+		}()
+	}
+
+	for i := range publicKeys {
+		indexes <- i
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	return sharedSecrets, errs
+}
@@ -0,0 +1,216 @@
+package ecdh25519_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func generatePublicKeys(tb testing.TB, n int) []ecdh25519.PublicKey {
+	tb.Helper()
+
+	publicKeys := make([]ecdh25519.PublicKey, n)
+	for i := range publicKeys {
+		publicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+		if err != nil {
+			tb.Fatal(err)
+		}
+
+		publicKeys[i] = publicKey
+	}
+
+	return publicKeys
+}
+
+func TestGenerateSharedSecretsBounded(t *testing.T) {
+	_, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicKeys := generatePublicKeys(t, 16)
+	publicKeys[5] = publicKeys[5][:16]
+
+	for _, maxConcurrency := range []int{0, 1, 4, 32} {
+		t.Run(strconv.Itoa(maxConcurrency), func(t *testing.T) {
+			sharedSecrets, errs := ecdh25519.GenerateSharedSecretsBounded(privateKey, publicKeys, maxConcurrency)
+
+			for i, publicKey := range publicKeys {
+				if i == 5 {
+					if !errors.Is(errs[i], ecdh25519.ErrBadPublicKeyLength) {
+						t.Errorf("GenerateSharedSecretsBounded() error %d = %v, want %v", i, errs[i], ecdh25519.ErrBadPublicKeyLength)
+					}
+
+					continue
+				}
+
+				if errs[i] != nil {
+					t.Errorf("GenerateSharedSecretsBounded() error %d = %v", i, errs[i])
+					continue
+				}
+
+				want, err := ecdh25519.GenerateSharedSecret(privateKey, publicKey)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !reflect.DeepEqual(sharedSecrets[i], want) {
+					t.Errorf("GenerateSharedSecretsBounded() secret %d = %v, want %v", i, sharedSecrets[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateSharedSecrets(t *testing.T) {
+	_, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, n := range []int{0, 3, 100} {
+		t.Run(strconv.Itoa(n), func(t *testing.T) {
+			publicKeys := generatePublicKeys(t, n)
+
+			sharedSecrets, err := ecdh25519.GenerateSharedSecrets(privateKey, publicKeys)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(sharedSecrets) != n {
+				t.Fatalf("GenerateSharedSecrets() returned %d secrets, want %d", len(sharedSecrets), n)
+			}
+
+			for i, publicKey := range publicKeys {
+				want, err := ecdh25519.GenerateSharedSecret(privateKey, publicKey)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !reflect.DeepEqual(sharedSecrets[i], want) {
+					t.Errorf("GenerateSharedSecrets() secret %d = %v, want %v", i, sharedSecrets[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateSharedSecrets_errors(t *testing.T) {
+	_, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	short := generatePublicKeys(t, 4)
+	short[2] = short[2][:16]
+
+	lowOrder := generatePublicKeys(t, 100)
+	lowOrder[70] = make([]byte, ecdh25519.PublicKeySize)
+
+	tests := []struct {
+		name       string
+		privateKey ecdh25519.PrivateKey
+		publicKeys []ecdh25519.PublicKey
+		wantErr    error
+		wantIndex  string
+	}{
+		{
+			name:       "with short private key",
+			privateKey: privateKey[:16],
+			publicKeys: generatePublicKeys(t, 2),
+			wantErr:    ecdh25519.ErrBadPrivateKeyLength,
+		},
+		{
+			name:       "with short public key",
+			privateKey: privateKey,
+			publicKeys: short,
+			wantErr:    ecdh25519.ErrBadPublicKeyLength,
+			wantIndex:  "index 2",
+		},
+		{
+			name:       "with low order public key",
+			privateKey: privateKey,
+			publicKeys: lowOrder,
+			wantErr:    ecdh25519.ErrLowOrderPoint,
+			wantIndex:  "index 70",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ecdh25519.GenerateSharedSecrets(tt.privateKey, tt.publicKeys)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GenerateSharedSecrets() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !strings.Contains(err.Error(), tt.wantIndex) {
+				t.Errorf("GenerateSharedSecrets() error = %v, want %q", err, tt.wantIndex)
+			}
+
+			if got != nil {
+				t.Errorf("GenerateSharedSecrets() = %v, want nil", got)
+			}
+		})
+	}
+}
+
+func TestGenerateSharedSecrets_zeroizesOnError(t *testing.T) {
+	_, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicKeys := generatePublicKeys(t, 4)
+	publicKeys[2] = make([]byte, ecdh25519.PublicKeySize)
+
+	var computed []ecdh25519.SharedSecret
+	restore := ecdh25519.SetGenerateSharedSecretsBounded(func(privateKey ecdh25519.PrivateKey, publicKeys []ecdh25519.PublicKey, maxConcurrency int) ([]ecdh25519.SharedSecret, []error) {
+		sharedSecrets, errs := ecdh25519.GenerateSharedSecretsBounded(privateKey, publicKeys, maxConcurrency)
+		computed = append(computed, sharedSecrets...)
+		return sharedSecrets, errs
+	})
+	defer restore()
+
+	if _, err := ecdh25519.GenerateSharedSecrets(privateKey, publicKeys); !errors.Is(err, ecdh25519.ErrLowOrderPoint) {
+		t.Fatalf("GenerateSharedSecrets() error = %v, want %v", err, ecdh25519.ErrLowOrderPoint)
+	}
+
+	for i, sharedSecret := range computed {
+		if i == 2 {
+			continue
+		}
+
+		if len(sharedSecret) != ecdh25519.SharedSecretSize {
+			t.Fatalf("secret %d length = %d, want %d", i, len(sharedSecret), ecdh25519.SharedSecretSize)
+		}
+
+		if !bytes.Equal(sharedSecret, make([]byte, ecdh25519.SharedSecretSize)) {
+			t.Errorf("secret %d = %x after the batch failed, want zeroized", i, sharedSecret)
+		}
+	}
+}
+
+func BenchmarkGenerateSharedSecretsBounded(b *testing.B) {
+	_, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	publicKeys := generatePublicKeys(b, 256)
+
+	for _, maxConcurrency := range []int{1, 2, 4, 8} {
+		b.Run(strconv.Itoa(maxConcurrency), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sharedSecrets, _ := ecdh25519.GenerateSharedSecretsBounded(privateKey, publicKeys, maxConcurrency)
+				benchmarkSink ^= sharedSecrets[0][0]
+			}
+		})
+	}
+}
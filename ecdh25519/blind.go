@@ -0,0 +1,58 @@
+package ecdh25519
+
+import (
+	"errors"
+	"fmt"
+)
+
+// BlindSize is the size, in bytes, of blinding scalars as used in this package.
+const BlindSize = 32
+
+var ErrBadBlindLength = errors.New("ecdh25519: bad blind length")
+
+// MaskPublicKey blinds publicKey with a blinding scalar, returning blind * publicKey.
+// This is the per-hop group element update of the Sphinx packet format, where
+// each hop re-randomizes the sender's ephemeral key so intermediaries cannot
+// link it across hops.
+//
+// Like every scalar passed to ScalarMult, blind is clamped before use. For a
+// 32-byte blind this keeps it nonzero modulo the group order, since clamped
+// scalars are multiples of the cofactor between 2^254 and 2^255.
+func MaskPublicKey(publicKey PublicKey, blind []byte) ([]byte, error) {
+	if l := len(publicKey); l != PublicKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	if l := len(blind); l != BlindSize {
+		return nil, fmt.Errorf("%w: %d", ErrBadBlindLength, l)
+	}
+
+	return ScalarMult(blind, publicKey)
+}
+
+// UnmaskSharedSecret computes the shared secret between privateKey and a
+// publicKey that is, or must be, masked by blinds.
+//
+// A hop receiving a masked key calls it with no blinds. A sender computing the
+// secret for a later hop passes that hop's public key along with every blind
+// applied to its ephemeral key so far, in any order: scalar multiplication
+// commutes, so both sides arrive at the same point. The intermediate values are
+// zeroized as soon as the next blind has been applied.
+func UnmaskSharedSecret(privateKey PrivateKey, publicKey PublicKey, blinds ...[]byte) (SharedSecret, error) {
+	sharedSecret, err := GenerateSharedSecret(privateKey, publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, blind := range blinds {
+		masked, err := MaskPublicKey(PublicKey(sharedSecret), blind)
+		sharedSecret.Zeroize()
+		if err != nil {
+			return nil, err
+		}
+
+		sharedSecret = masked
+	}
+
+	return sharedSecret, nil
+}
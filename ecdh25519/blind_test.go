@@ -0,0 +1,92 @@
+package ecdh25519_test
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestUnmaskSharedSecret(t *testing.T) {
+	ephemeralPublicKey, ephemeralPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	firstHopPublicKey, firstHopPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secondHopPublicKey, secondHopPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blindFor := func(publicKey, sharedSecret []byte) []byte {
+		h := sha256.New()
+		h.Write(publicKey)
+		h.Write(sharedSecret)
+		return h.Sum(nil)
+	}
+
+	// Sender side: compute both hop secrets from the ephemeral key.
+	senderFirstSecret, err := ecdh25519.UnmaskSharedSecret(ephemeralPrivateKey, firstHopPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blind := blindFor(ephemeralPublicKey, senderFirstSecret)
+
+	senderSecondSecret, err := ecdh25519.UnmaskSharedSecret(ephemeralPrivateKey, secondHopPublicKey, blind)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// First hop: unmask, then blind the ephemeral key for the next hop.
+	firstSecret, err := ecdh25519.UnmaskSharedSecret(firstHopPrivateKey, ephemeralPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(firstSecret, senderFirstSecret) {
+		t.Errorf("first hop secret = %x, want %x", firstSecret, senderFirstSecret)
+	}
+
+	maskedPublicKey, err := ecdh25519.MaskPublicKey(ephemeralPublicKey, blindFor(ephemeralPublicKey, firstSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reflect.DeepEqual(maskedPublicKey, []byte(ephemeralPublicKey)) {
+		t.Errorf("MaskPublicKey() did not change the public key")
+	}
+
+	// Second hop: unmask the blinded ephemeral key.
+	secondSecret, err := ecdh25519.UnmaskSharedSecret(secondHopPrivateKey, maskedPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(secondSecret, senderSecondSecret) {
+		t.Errorf("second hop secret = %x, want %x", secondSecret, senderSecondSecret)
+	}
+}
+
+func TestMaskPublicKey(t *testing.T) {
+	publicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ecdh25519.MaskPublicKey(publicKey, make([]byte, 16)); !errors.Is(err, ecdh25519.ErrBadBlindLength) {
+		t.Errorf("MaskPublicKey() error = %v, want %v", err, ecdh25519.ErrBadBlindLength)
+	}
+
+	if _, err := ecdh25519.MaskPublicKey(publicKey[:16], make([]byte, 32)); !errors.Is(err, ecdh25519.ErrBadPublicKeyLength) {
+		t.Errorf("MaskPublicKey() error = %v, want %v", err, ecdh25519.ErrBadPublicKeyLength)
+	}
+}
@@ -0,0 +1,105 @@
+package ecdh25519
+
+import (
+	"crypto/cipher"
+	cryptorand "crypto/rand"
+	"errors"
+	"io"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+var (
+	ErrMalformedBox      = errors.New("ecdh25519: malformed box")
+	ErrBoxAuthentication = errors.New("ecdh25519: box authentication failed")
+)
+
+var boxInfo = []byte("ecdh25519 box")
+
+// BoxOverhead is the number of bytes SealBox adds to a message: a random
+// XChaCha20-Poly1305 nonce and the authentication tag.
+const BoxOverhead = chacha20poly1305.NonceSizeX + chacha20poly1305.Overhead
+
+func init() {
+	registerCapability("xchacha20poly1305")
+}
+
+// SealBox encrypts and authenticates message from a sender to a recipient, like
+// NaCl's crypto_box. It performs the key agreement between senderPrivateKey and
+// recipientPublicKey, derives an XChaCha20-Poly1305 key from the shared secret
+// and both public keys with HKDF-SHA256, and returns a random nonce followed by
+// the ciphertext.
+//
+// Anyone holding either private key can open the box, so it authenticates the
+// sender to the recipient but is not a signature: the recipient cannot prove to
+// a third party who sealed it.
+func SealBox(message []byte, recipientPublicKey PublicKey, senderPrivateKey PrivateKey) ([]byte, error) {
+	senderPublicKey, err := senderPrivateKey.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := boxAEAD(senderPrivateKey, recipientPublicKey, senderPublicKey, recipientPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, chacha20poly1305.NonceSizeX, chacha20poly1305.NonceSizeX+len(message)+chacha20poly1305.Overhead)
+	if _, err := io.ReadFull(cryptorand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, message, nil), nil
+}
+
+// OpenBox decrypts and authenticates a box produced by SealBox. It returns
+// ErrMalformedBox if box is too short to be valid, and ErrBoxAuthentication if
+// it was not sealed by the holder of senderPublicKey for this recipient or was
+// modified in transit.
+func OpenBox(box []byte, senderPublicKey PublicKey, recipientPrivateKey PrivateKey) ([]byte, error) {
+	if len(box) < BoxOverhead {
+		return nil, ErrMalformedBox
+	}
+
+	recipientPublicKey, err := recipientPrivateKey.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := boxAEAD(recipientPrivateKey, senderPublicKey, senderPublicKey, recipientPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, ciphertext := box[:chacha20poly1305.NonceSizeX], box[chacha20poly1305.NonceSizeX:]
+	message, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, ErrBoxAuthentication
+	}
+
+	return message, nil
+}
+
+// boxAEAD returns the XChaCha20-Poly1305 AEAD for a box between the holders of
+// senderPublicKey and recipientPublicKey, from either side of the exchange.
+func boxAEAD(privateKey PrivateKey, publicKey, senderPublicKey, recipientPublicKey PublicKey) (cipher.AEAD, error) {
+	sharedSecret, err := GenerateSharedSecret(privateKey, publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	defer sharedSecret.Zeroize()
+
+	salt := make([]byte, 0, 2*PublicKeySize)
+	salt = append(salt, senderPublicKey...)
+	salt = append(salt, recipientPublicKey...)
+
+	key := make([]byte, chacha20poly1305.KeySize)
+	defer zeroize(key)
+
+	if err := hkdfExpand(key, sharedSecret, salt, boxInfo); err != nil {
+		return nil, err
+	}
+
+	return chacha20poly1305.NewX(key)
+}
@@ -0,0 +1,99 @@
+package ecdh25519_test
+
+import (
+	"crypto/rand"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestSealBox(t *testing.T) {
+	senderPublicKey, senderPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recipientPublicKey, recipientPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherPublicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := []byte("hello")
+
+	box, err := ecdh25519.SealBox(message, recipientPublicKey, senderPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(box); l != len(message)+ecdh25519.BoxOverhead {
+		t.Errorf("SealBox() returned %d bytes, want %d", l, len(message)+ecdh25519.BoxOverhead)
+	}
+
+	got, err := ecdh25519.OpenBox(box, senderPublicKey, recipientPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, message) {
+		t.Errorf("OpenBox() = %v, want %v", got, message)
+	}
+
+	again, err := ecdh25519.SealBox(message, recipientPublicKey, senderPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reflect.DeepEqual(box, again) {
+		t.Errorf("SealBox() returned the same box twice")
+	}
+
+	tampered := append([]byte(nil), box...)
+	tampered[len(tampered)-1] ^= 1
+
+	tests := []struct {
+		name            string
+		box             []byte
+		senderPublicKey ecdh25519.PublicKey
+		wantErr         error
+	}{
+		{
+			name:            "with tampered box",
+			box:             tampered,
+			senderPublicKey: senderPublicKey,
+			wantErr:         ecdh25519.ErrBoxAuthentication,
+		},
+		{
+			name:            "with wrong sender",
+			box:             box,
+			senderPublicKey: otherPublicKey,
+			wantErr:         ecdh25519.ErrBoxAuthentication,
+		},
+		{
+			name:            "with short box",
+			box:             box[:ecdh25519.BoxOverhead-1],
+			senderPublicKey: senderPublicKey,
+			wantErr:         ecdh25519.ErrMalformedBox,
+		},
+		{
+			name:            "with short sender public key",
+			box:             box,
+			senderPublicKey: senderPublicKey[:16],
+			wantErr:         ecdh25519.ErrBadPublicKeyLength,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ecdh25519.OpenBox(tt.box, tt.senderPublicKey, recipientPrivateKey); !errors.Is(err, tt.wantErr) {
+				t.Errorf("OpenBox() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package ecdh25519
+
+import (
+	"sort"
+
+	"github.com/adnsio/ecdh"
+)
+
+// capabilities holds the operations and formats supported by this package.
+// Each feature file adds its own from an init function, so the set follows
+// what is compiled in.
+var capabilities = make(map[string]struct{})
+
+// registerCapability adds name to the capabilities. It must only be called
+// from init functions.
+func registerCapability(name string) {
+	capabilities[name] = struct{}{}
+}
+
+// Capabilities returns the sorted names of the operations and formats supported
+// by this package, and of the key agreement schemes registered with
+// ecdh.Register by the scheme packages compiled into the program, for feature
+// negotiation and diagnostics.
+func Capabilities() []string {
+	c := make([]string, 0, len(capabilities))
+	for name := range capabilities {
+		c = append(c, name)
+	}
+
+	for _, name := range ecdh.Schemes() {
+		if _, ok := capabilities[name]; !ok {
+			c = append(c, name)
+		}
+	}
+
+	sort.Strings(c)
+	return c
+}
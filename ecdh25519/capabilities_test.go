@@ -0,0 +1,41 @@
+package ecdh25519_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+	_ "github.com/adnsio/ecdh/ecdh448"
+	_ "github.com/adnsio/ecdh/ecdhp256"
+)
+
+func TestCapabilities(t *testing.T) {
+	got := ecdh25519.Capabilities()
+
+	if !sort.StringsAreSorted(got) {
+		t.Errorf("Capabilities() = %v, want sorted", got)
+	}
+
+	for i := 1; i < len(got); i++ {
+		if got[i] == got[i-1] {
+			t.Errorf("Capabilities() = %v, lists %v twice", got, got[i])
+		}
+	}
+
+	for _, want := range []string{
+		"x25519", "x448", "p256",
+		"hkdf-sha256", "chacha20poly1305", "xchacha20poly1305", "scrypt",
+		"ed25519-certificate", "ed25519-conversion", "emoji-sas",
+		"raw", "hex", "base64", "pem", "multibase", "did",
+		"openssh", "json", "spki", "crypto/ecdh",
+	} {
+		if i := sort.SearchStrings(got, want); i == len(got) || got[i] != want {
+			t.Errorf("Capabilities() = %v, missing %v", got, want)
+		}
+	}
+
+	got[0] = "modified"
+	if again := ecdh25519.Capabilities(); again[0] == "modified" {
+		t.Errorf("Capabilities() returned a shared slice")
+	}
+}
@@ -0,0 +1,117 @@
+package ecdh25519
+
+import (
+	"crypto/ed25519"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	certificateVersion = 1
+	// certificateBodySize is the size of the signed part of a certificate:
+	// version, X25519 public key, Ed25519 public key and expiry.
+	certificateBodySize = 1 + PublicKeySize + ed25519.PublicKeySize + 8
+	// CertificateSize is the size, in bytes, of certificates as used in this package.
+	CertificateSize = certificateBodySize + ed25519.SignatureSize
+)
+
+var (
+	ErrBadSignerKeyLength      = errors.New("ecdh25519: bad signer key length")
+	ErrMalformedCertificate    = errors.New("ecdh25519: malformed certificate")
+	ErrBadCertificateSignature = errors.New("ecdh25519: bad certificate signature")
+	ErrCertificateExpired      = errors.New("ecdh25519: certificate expired")
+)
+
+var certificateLabel = []byte("ecdh25519 certificate")
+
+func init() {
+	registerCapability("ed25519-certificate")
+}
+
+// GenerateCertifiedKeyPair generates a public/private key pair using entropy
+// from rand and certifies the public key with signer, valid until notAfter.
+// If rand is nil, crypto/rand.Reader will be used.
+//
+// The certificate is a fixed-size encoding of a version byte, the X25519
+// public key, the Ed25519 public key of the signer, the expiry as big-endian
+// Unix seconds and an Ed25519 signature over all of them.
+func GenerateCertifiedKeyPair(rand io.Reader, signer ed25519.PrivateKey, notAfter time.Time) (*KeyPair, []byte, error) {
+	if l := len(signer); l != ed25519.PrivateKeySize {
+		return nil, nil, fmt.Errorf("%w: %d", ErrBadSignerKeyLength, l)
+	}
+
+	publicKey, privateKey, err := GenerateKeyPair(rand)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certificate := make([]byte, certificateBodySize, CertificateSize)
+	certificate[0] = certificateVersion
+	copy(certificate[1:], publicKey)
+	copy(certificate[1+PublicKeySize:], signer.Public().(ed25519.PublicKey))
+	binary.BigEndian.PutUint64(certificate[1+PublicKeySize+ed25519.PublicKeySize:], uint64(notAfter.Unix()))
+	certificate = append(certificate, ed25519.Sign(signer, certificateMessage(certificate))...)
+
+	return &KeyPair{PublicKey: publicKey, PrivateKey: privateKey}, certificate, nil
+}
+
+// VerifyCertificate checks the signature and expiry of a certificate produced
+// by GenerateCertifiedKeyPair and returns the certified public key.
+//
+// VerifyCertificate only proves that the certificate was signed by the Ed25519
+// key it embeds. Callers must check that key against the signers they trust,
+// as returned by CertificateSigner.
+func VerifyCertificate(certificate []byte) (PublicKey, error) {
+	if err := parseCertificate(certificate); err != nil {
+		return nil, err
+	}
+
+	signer := ed25519.PublicKey(certificate[1+PublicKeySize : 1+PublicKeySize+ed25519.PublicKeySize])
+	if !ed25519.Verify(signer, certificateMessage(certificate[:certificateBodySize]), certificate[certificateBodySize:]) {
+		return nil, ErrBadCertificateSignature
+	}
+
+	notAfter := int64(binary.BigEndian.Uint64(certificate[1+PublicKeySize+ed25519.PublicKeySize:]))
+	if time.Now().Unix() > notAfter {
+		return nil, ErrCertificateExpired
+	}
+
+	publicKey := make(PublicKey, PublicKeySize)
+	copy(publicKey, certificate[1:])
+
+	return publicKey, nil
+}
+
+// CertificateSigner returns the Ed25519 public key that a certificate claims to
+// be signed by. It does not verify the certificate.
+func CertificateSigner(certificate []byte) (ed25519.PublicKey, error) {
+	if err := parseCertificate(certificate); err != nil {
+		return nil, err
+	}
+
+	signer := make(ed25519.PublicKey, ed25519.PublicKeySize)
+	copy(signer, certificate[1+PublicKeySize:])
+
+	return signer, nil
+}
+
+func parseCertificate(certificate []byte) error {
+	if l := len(certificate); l != CertificateSize {
+		return fmt.Errorf("%w: bad length: %d", ErrMalformedCertificate, l)
+	}
+
+	if v := certificate[0]; v != certificateVersion {
+		return fmt.Errorf("%w: unknown version: %d", ErrMalformedCertificate, v)
+	}
+
+	return nil
+}
+
+func certificateMessage(body []byte) []byte {
+	message := make([]byte, 0, len(certificateLabel)+len(body))
+	message = append(message, certificateLabel...)
+	return append(message, body...)
+}
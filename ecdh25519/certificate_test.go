@@ -0,0 +1,114 @@
+package ecdh25519_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestGenerateCertifiedKeyPair(t *testing.T) {
+	signerPublicKey, signerPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyPair, certificate, err := ecdh25519.GenerateCertifiedKeyPair(rand.Reader, signerPrivateKey, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ecdh25519.VerifyCertificate(certificate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, keyPair.PublicKey) {
+		t.Errorf("VerifyCertificate() = %v, want %v", got, keyPair.PublicKey)
+	}
+
+	signer, err := ecdh25519.CertificateSigner(certificate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !signer.Equal(signerPublicKey) {
+		t.Errorf("CertificateSigner() = %v, want %v", signer, signerPublicKey)
+	}
+
+	if _, _, err := ecdh25519.GenerateCertifiedKeyPair(rand.Reader, signerPrivateKey[:16], time.Now()); !errors.Is(err, ecdh25519.ErrBadSignerKeyLength) {
+		t.Errorf("GenerateCertifiedKeyPair() error = %v, want %v", err, ecdh25519.ErrBadSignerKeyLength)
+	}
+}
+
+func TestVerifyCertificate(t *testing.T) {
+	_, signerPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, certificate, err := ecdh25519.GenerateCertifiedKeyPair(rand.Reader, signerPrivateKey, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, expired, err := ecdh25519.GenerateCertifiedKeyPair(rand.Reader, signerPrivateKey, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tamper := func(i int) []byte {
+		b := append([]byte(nil), certificate...)
+		b[i] ^= 1
+		return b
+	}
+
+	tests := []struct {
+		name        string
+		certificate []byte
+		wantErr     error
+	}{
+		{
+			name:        "with tampered public key",
+			certificate: tamper(1),
+			wantErr:     ecdh25519.ErrBadCertificateSignature,
+		},
+		{
+			name:        "with tampered expiry",
+			certificate: tamper(ecdh25519.CertificateSize - ed25519.SignatureSize - 1),
+			wantErr:     ecdh25519.ErrBadCertificateSignature,
+		},
+		{
+			name:        "with tampered signature",
+			certificate: tamper(ecdh25519.CertificateSize - 1),
+			wantErr:     ecdh25519.ErrBadCertificateSignature,
+		},
+		{
+			name:        "with unknown version",
+			certificate: tamper(0),
+			wantErr:     ecdh25519.ErrMalformedCertificate,
+		},
+		{
+			name:        "with short certificate",
+			certificate: certificate[:16],
+			wantErr:     ecdh25519.ErrMalformedCertificate,
+		},
+		{
+			name:        "with expired certificate",
+			certificate: expired,
+			wantErr:     ecdh25519.ErrCertificateExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ecdh25519.VerifyCertificate(tt.certificate); !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyCertificate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
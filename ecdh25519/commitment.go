@@ -0,0 +1,58 @@
+package ecdh25519
+
+import (
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"io"
+)
+
+// commitmentNonceSize is the size, in bytes, of the random value that hides a
+// committed public key.
+const commitmentNonceSize = 32
+
+var commitmentLabel = []byte("ecdh25519 commitment")
+
+// Commit returns a hiding commitment to publicKey and the opening that reveals
+// it later. The opening is a fresh random value followed by the public key, and
+// the commitment is its SHA-256 hash, so the commitment reveals nothing about
+// the key until the opening is published.
+func Commit(publicKey PublicKey) (commitment, opening []byte, err error) {
+	if l := len(publicKey); l != PublicKeySize {
+		return nil, nil, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	opening = make([]byte, commitmentNonceSize+PublicKeySize)
+	if _, err := io.ReadFull(cryptorand.Reader, opening[:commitmentNonceSize]); err != nil {
+		return nil, nil, err
+	}
+
+	copy(opening[commitmentNonceSize:], publicKey)
+
+	return commitmentHash(opening), opening, nil
+}
+
+// Open checks that opening matches commitment and, if it does, returns the
+// committed public key.
+func Open(commitment, opening []byte) (PublicKey, bool) {
+	if len(opening) != commitmentNonceSize+PublicKeySize {
+		return nil, false
+	}
+
+	if subtle.ConstantTimeCompare(commitmentHash(opening), commitment) != 1 {
+		return nil, false
+	}
+
+	publicKey := make(PublicKey, PublicKeySize)
+	copy(publicKey, opening[commitmentNonceSize:])
+
+	return publicKey, true
+}
+
+func commitmentHash(opening []byte) []byte {
+	h := sha256.New()
+	h.Write(commitmentLabel)
+	h.Write(opening)
+	return h.Sum(nil)
+}
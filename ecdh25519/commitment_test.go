@@ -0,0 +1,102 @@
+package ecdh25519_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestCommit(t *testing.T) {
+	publicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitment, opening, err := ecdh25519.Commit(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Contains(commitment, publicKey) {
+		t.Errorf("Commit() commitment contains the public key")
+	}
+
+	got, ok := ecdh25519.Open(commitment, opening)
+	if !ok {
+		t.Fatalf("Open() = false, want true")
+	}
+
+	if !reflect.DeepEqual(got, publicKey) {
+		t.Errorf("Open() = %v, want %v", got, publicKey)
+	}
+
+	otherCommitment, _, err := ecdh25519.Commit(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reflect.DeepEqual(commitment, otherCommitment) {
+		t.Errorf("Commit() returned the same commitment twice")
+	}
+
+	if _, _, err := ecdh25519.Commit(publicKey[:16]); !errors.Is(err, ecdh25519.ErrBadPublicKeyLength) {
+		t.Errorf("Commit() error = %v, want %v", err, ecdh25519.ErrBadPublicKeyLength)
+	}
+}
+
+func TestOpen_tampered(t *testing.T) {
+	publicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitment, opening, err := ecdh25519.Commit(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tamper := func(b []byte, i int) []byte {
+		b = append([]byte(nil), b...)
+		b[i] ^= 1
+		return b
+	}
+
+	tests := []struct {
+		name       string
+		commitment []byte
+		opening    []byte
+	}{
+		{
+			name:       "with tampered commitment",
+			commitment: tamper(commitment, 0),
+			opening:    opening,
+		},
+		{
+			name:       "with tampered nonce",
+			commitment: commitment,
+			opening:    tamper(opening, 0),
+		},
+		{
+			name:       "with tampered public key",
+			commitment: commitment,
+			opening:    tamper(opening, len(opening)-1),
+		},
+		{
+			name:       "with short opening",
+			commitment: commitment,
+			opening:    opening[:32],
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := ecdh25519.Open(tt.commitment, tt.opening); ok {
+				t.Errorf("Open() = true, want false")
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package ecdh25519
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var (
+	ErrUnknownKeyFormat   = errors.New("ecdh25519: unknown key format")
+	ErrMalformedPublicKey = errors.New("ecdh25519: malformed public key")
+)
+
+// Public key formats supported by ConvertPublicKey.
+const (
+	FormatRaw       = "raw"
+	FormatHex       = "hex"
+	FormatBase64    = "base64"
+	FormatPEM       = "pem"
+	FormatMultibase = "multibase"
+	FormatDID       = "did"
+)
+
+var publicKeyFormats = []string{FormatRaw, FormatHex, FormatBase64, FormatPEM, FormatMultibase, FormatDID}
+
+func init() {
+	for _, format := range publicKeyFormats {
+		registerCapability(format)
+	}
+}
+
+const (
+	// multibaseBase58BTC is the multibase prefix of base58btc strings.
+	multibaseBase58BTC = 'z'
+	// didKeyPrefix is the prefix of did:key identifiers.
+	didKeyPrefix = "did:key:"
+)
+
+// multicodecX25519Pub is the varint encoded multicodec code of X25519 public keys.
+var multicodecX25519Pub = []byte{0xec, 0x01}
+
+// maxBase58KeyLength is the length of the longest base58 encoding of a
+// multicodec x25519 key, ceil(34 * log(256) / log(58)). Longer input is
+// rejected before decoding, which takes quadratic time.
+const maxBase58KeyLength = 47
+
+// ConvertPublicKey parses input as a public key in the from format and
+// re-encodes it in the to format. The supported formats are:
+//
+//	raw        the 32 raw bytes
+//	hex        hex encoding
+//	base64     standard base64 encoding, with padding
+//	pem        a PublicKeyPEMType PEM block
+//	multibase  base58btc multibase of the x25519-pub multicodec key
+//	did        did:key identifier
+func ConvertPublicKey(input []byte, from, to string) ([]byte, error) {
+	publicKey, err := decodePublicKey(input, from)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodePublicKey(publicKey, to)
+}
+
+func decodePublicKey(input []byte, format string) (PublicKey, error) {
+	var (
+		b   []byte
+		err error
+	)
+
+	switch format {
+	case FormatRaw:
+		b = input
+	case FormatHex:
+		b, err = hex.DecodeString(strings.TrimSpace(string(input)))
+	case FormatBase64:
+		b, err = base64.StdEncoding.DecodeString(strings.TrimSpace(string(input)))
+	case FormatPEM:
+		block, _ := pem.Decode(input)
+		if block == nil || block.Type != PublicKeyPEMType {
+			return nil, fmt.Errorf("%w: no %s block", ErrMalformedPublicKey, PublicKeyPEMType)
+		}
+
+		b = block.Bytes
+	case FormatMultibase:
+		b, err = decodeMultibase(strings.TrimSpace(string(input)))
+	case FormatDID:
+		s := strings.TrimSpace(string(input))
+		if !strings.HasPrefix(s, didKeyPrefix) {
+			return nil, fmt.Errorf("%w: missing %s prefix", ErrMalformedPublicKey, didKeyPrefix)
+		}
+
+		b, err = decodeMultibase(strings.TrimPrefix(s, didKeyPrefix))
+	default:
+		return nil, unknownKeyFormat(format)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrMalformedPublicKey, format, err)
+	}
+
+	if l := len(b); l != PublicKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	publicKey := make(PublicKey, PublicKeySize)
+	copy(publicKey, b)
+
+	return publicKey, nil
+}
+
+func encodePublicKey(publicKey PublicKey, format string) ([]byte, error) {
+	switch format {
+	case FormatRaw:
+		return publicKey, nil
+	case FormatHex:
+		return []byte(hex.EncodeToString(publicKey)), nil
+	case FormatBase64:
+		return []byte(base64.StdEncoding.EncodeToString(publicKey)), nil
+	case FormatPEM:
+		return pem.EncodeToMemory(&pem.Block{Type: PublicKeyPEMType, Bytes: publicKey}), nil
+	case FormatMultibase:
+		return []byte(encodeMultibase(publicKey)), nil
+	case FormatDID:
+		return []byte(didKeyPrefix + encodeMultibase(publicKey)), nil
+	default:
+		return nil, unknownKeyFormat(format)
+	}
+}
+
+func unknownKeyFormat(format string) error {
+	return fmt.Errorf("%w %q, supported formats: %s", ErrUnknownKeyFormat, format, strings.Join(publicKeyFormats, ", "))
+}
+
+func encodeMultibase(publicKey PublicKey) string {
+	b := make([]byte, 0, len(multicodecX25519Pub)+PublicKeySize)
+	b = append(b, multicodecX25519Pub...)
+	b = append(b, publicKey...)
+
+	return string(multibaseBase58BTC) + encodeBase58(b)
+}
+
+func decodeMultibase(s string) ([]byte, error) {
+	if len(s) == 0 || s[0] != multibaseBase58BTC {
+		return nil, errors.New("unsupported multibase encoding")
+	}
+
+	if len(s)-1 > maxBase58KeyLength {
+		return nil, errors.New("multibase key too long")
+	}
+
+	b, err := decodeBase58(s[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) < len(multicodecX25519Pub) || string(b[:len(multicodecX25519Pub)]) != string(multicodecX25519Pub) {
+		return nil, errors.New("not an x25519-pub multicodec key")
+	}
+
+	return b[len(multicodecX25519Pub):], nil
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var base58Radix = big.NewInt(58)
+
+// encodeBase58 encodes b with the Bitcoin base58 alphabet. Public keys are not
+// secret, so math/big is fine here.
+func encodeBase58(b []byte) string {
+	n := new(big.Int).SetBytes(b)
+	mod := new(big.Int)
+
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, base58Radix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+
+	for _, v := range b {
+		if v != 0 {
+			break
+		}
+
+		out = append(out, base58Alphabet[0])
+	}
+
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+
+	return string(out)
+}
+
+func decodeBase58(s string) ([]byte, error) {
+	n := new(big.Int)
+	for _, c := range []byte(s) {
+		i := strings.IndexByte(base58Alphabet, c)
+		if i < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", c)
+		}
+
+		n.Mul(n, base58Radix)
+		n.Add(n, big.NewInt(int64(i)))
+	}
+
+	var zeros int
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
@@ -0,0 +1,124 @@
+package ecdh25519_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestConvertPublicKey(t *testing.T) {
+	raw, err := hex.DecodeString("8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encodings := map[string][]byte{
+		ecdh25519.FormatRaw:       raw,
+		ecdh25519.FormatHex:       []byte("8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a"),
+		ecdh25519.FormatBase64:    []byte("hSDwCYkwp1R0i33ctD73Wg2/Og0mOBr066SpjqqbTmo="),
+		ecdh25519.FormatPEM:       []byte("-----BEGIN X25519 PUBLIC KEY-----\nhSDwCYkwp1R0i33ctD73Wg2/Og0mOBr066SpjqqbTmo=\n-----END X25519 PUBLIC KEY-----\n"),
+		ecdh25519.FormatMultibase: []byte("z6LSkdrX4EvewpktHBjvNxRDogPdC5iVF8LT3LPKefGAgi89"),
+		ecdh25519.FormatDID:       []byte("did:key:z6LSkdrX4EvewpktHBjvNxRDogPdC5iVF8LT3LPKefGAgi89"),
+	}
+
+	for from, input := range encodings {
+		for to, want := range encodings {
+			t.Run(from+" to "+to, func(t *testing.T) {
+				got, err := ecdh25519.ConvertPublicKey(input, from, to)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("ConvertPublicKey() = %q, want %q", got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestConvertPublicKey_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		from    string
+		to      string
+		wantErr error
+	}{
+		{
+			name:    "with unknown source format",
+			input:   "00",
+			from:    "jwk",
+			to:      ecdh25519.FormatHex,
+			wantErr: ecdh25519.ErrUnknownKeyFormat,
+		},
+		{
+			name:    "with unknown target format",
+			input:   "8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a",
+			from:    ecdh25519.FormatHex,
+			to:      "jwk",
+			wantErr: ecdh25519.ErrUnknownKeyFormat,
+		},
+		{
+			name:    "with malformed hex",
+			input:   "zz",
+			from:    ecdh25519.FormatHex,
+			to:      ecdh25519.FormatRaw,
+			wantErr: ecdh25519.ErrMalformedPublicKey,
+		},
+		{
+			name:    "with short key",
+			input:   "8520f009",
+			from:    ecdh25519.FormatHex,
+			to:      ecdh25519.FormatRaw,
+			wantErr: ecdh25519.ErrBadPublicKeyLength,
+		},
+		{
+			name:    "with ed25519 multicodec",
+			input:   "z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK",
+			from:    ecdh25519.FormatMultibase,
+			to:      ecdh25519.FormatRaw,
+			wantErr: ecdh25519.ErrMalformedPublicKey,
+		},
+		{
+			name:    "with oversized multibase",
+			input:   "z" + strings.Repeat("z", 1<<20),
+			from:    ecdh25519.FormatMultibase,
+			to:      ecdh25519.FormatRaw,
+			wantErr: ecdh25519.ErrMalformedPublicKey,
+		},
+		{
+			name:    "with oversized did",
+			input:   "did:key:z" + strings.Repeat("z", 1<<20),
+			from:    ecdh25519.FormatDID,
+			to:      ecdh25519.FormatRaw,
+			wantErr: ecdh25519.ErrMalformedPublicKey,
+		},
+		{
+			name:    "with missing did prefix",
+			input:   "z6LSkdrX4EvewpktHBjvNxRDogPdC5iVF8LT3LPKefGAgi89",
+			from:    ecdh25519.FormatDID,
+			to:      ecdh25519.FormatRaw,
+			wantErr: ecdh25519.ErrMalformedPublicKey,
+		},
+		{
+			name:    "with missing pem block",
+			input:   "not pem",
+			from:    ecdh25519.FormatPEM,
+			to:      ecdh25519.FormatRaw,
+			wantErr: ecdh25519.ErrMalformedPublicKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ecdh25519.ConvertPublicKey([]byte(tt.input), tt.from, tt.to); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ConvertPublicKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package ecdh25519
+
+import "errors"
+
+var ErrEmptyFingerprint = errors.New("ecdh25519: empty device fingerprint")
+
+var deviceKeyInfo = []byte("ecdh25519 device key")
+
+// DeviceKeyPair deterministically derives a public/private key pair from a
+// device fingerprint and a salt using HKDF-SHA256, or the KDF chosen with opts,
+// so the same device always produces the same key.
+//
+// The fingerprint must be stable across restarts and kept secret: anyone who
+// knows it and the salt can recompute the private key. This is not a substitute
+// for keys generated and kept inside dedicated hardware.
+func DeviceKeyPair(fingerprint, salt []byte, opts ...DeriveOption) (PublicKey, PrivateKey, error) {
+	if len(fingerprint) == 0 {
+		return nil, nil, ErrEmptyFingerprint
+	}
+
+	c, err := resolveDeriveOptions(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	seed, err := c.deriveKey(fingerprint, salt, deviceKeyInfo, SeedSize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer zeroize(seed)
+
+	return NewPrivateKeyFromSeed(seed)
+}
@@ -0,0 +1,50 @@
+package ecdh25519_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestDeviceKeyPair(t *testing.T) {
+	fingerprint := []byte("device-serial-0001")
+	salt := []byte("server salt")
+
+	publicKey, privateKey, err := ecdh25519.DeviceKeyPair(fingerprint, salt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	againPublicKey, againPrivateKey, err := ecdh25519.DeviceKeyPair(fingerprint, salt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(privateKey, againPrivateKey) || !reflect.DeepEqual(publicKey, againPublicKey) {
+		t.Errorf("DeviceKeyPair() is not deterministic")
+	}
+
+	otherPublicKey, _, err := ecdh25519.DeviceKeyPair(fingerprint, []byte("other salt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reflect.DeepEqual(publicKey, otherPublicKey) {
+		t.Errorf("DeviceKeyPair() returned the same key for different salts")
+	}
+
+	derivedPublicKey, err := privateKey.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(publicKey, derivedPublicKey) {
+		t.Errorf("DeviceKeyPair() public key = %v, want %v", publicKey, derivedPublicKey)
+	}
+
+	if _, _, err := ecdh25519.DeviceKeyPair(nil, salt); !errors.Is(err, ecdh25519.ErrEmptyFingerprint) {
+		t.Errorf("DeviceKeyPair() error = %v, want %v", err, ecdh25519.ErrEmptyFingerprint)
+	}
+}
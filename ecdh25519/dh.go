@@ -0,0 +1,32 @@
+package ecdh25519
+
+import "io"
+
+// DH is the set of key agreement operations that protocol code needs. X25519
+// implements it with this package; tests can substitute a deterministic fake
+// such as ecdhtest.Mock.
+type DH interface {
+	// GenerateKeyPair generates a public/private key pair using entropy from rand.
+	GenerateKeyPair(rand io.Reader) (PublicKey, PrivateKey, error)
+	// SharedSecret generates a shared secret by using someone else's public key.
+	SharedSecret(privateKey PrivateKey, publicKey PublicKey) (SharedSecret, error)
+	// PublicKeyFromPrivate returns the PublicKey corresponding to privateKey.
+	PublicKeyFromPrivate(privateKey PrivateKey) (PublicKey, error)
+}
+
+// X25519 is the DH implemented by this package.
+var X25519 DH = x25519{}
+
+type x25519 struct{}
+
+func (x25519) GenerateKeyPair(rand io.Reader) (PublicKey, PrivateKey, error) {
+	return GenerateKeyPair(rand)
+}
+
+func (x25519) SharedSecret(privateKey PrivateKey, publicKey PublicKey) (SharedSecret, error) {
+	return GenerateSharedSecret(privateKey, publicKey)
+}
+
+func (x25519) PublicKeyFromPrivate(privateKey PrivateKey) (PublicKey, error) {
+	return privateKey.PublicKey()
+}
@@ -0,0 +1,46 @@
+package ecdh25519_test
+
+import (
+	"crypto/rand"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestX25519(t *testing.T) {
+	dh := ecdh25519.X25519
+
+	alicePublicKey, alicePrivateKey, err := dh.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bobPublicKey, bobPrivateKey, err := dh.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	derivedPublicKey, err := dh.PublicKeyFromPrivate(alicePrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(derivedPublicKey, alicePublicKey) {
+		t.Errorf("X25519.PublicKeyFromPrivate() = %v, want %v", derivedPublicKey, alicePublicKey)
+	}
+
+	aliceSharedSecret, err := dh.SharedSecret(alicePrivateKey, bobPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bobSharedSecret, err := dh.SharedSecret(bobPrivateKey, alicePublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(aliceSharedSecret, bobSharedSecret) {
+		t.Errorf("X25519.SharedSecret() = %v, want %v", aliceSharedSecret, bobSharedSecret)
+	}
+}
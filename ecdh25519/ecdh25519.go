@@ -3,10 +3,14 @@
 package ecdh25519
 
 import (
+	"crypto"
 	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"io"
+	"math/bits"
 
 	"golang.org/x/crypto/curve25519"
 )
@@ -16,39 +20,247 @@ const (
 	PublicKeySize = 32
 	// PrivateKeySize is the size, in bytes, of private keys as used in this package.
 	PrivateKeySize = 32
+	// SeedSize is the size, in bytes, of seeds as used in this package.
+	SeedSize = 32
+	// SharedSecretSize is the size, in bytes, of shared secrets as used in this package.
+	SharedSecretSize = 32
+
+	// entropyReadAttempts is how many times GenerateKeyPair reads from rand
+	// before giving up on a source that keeps returning short reads.
+	entropyReadAttempts = 3
 )
 
 var (
 	ErrBadPrivateKeyLength = errors.New("ecdh25519: bad private key length")
 	ErrBadPublicKeyLength  = errors.New("ecdh25519: bad public key length")
+	ErrNoEntropySources    = errors.New("ecdh25519: no entropy sources")
+	ErrSuspiciousEntropy   = errors.New("ecdh25519: suspicious entropy")
+	ErrLowOrderPoint       = errors.New("ecdh25519: low order point")
+	ErrBadSeedLength       = errors.New("ecdh25519: bad seed length")
+	ErrShortEntropyRead    = errors.New("ecdh25519: short entropy read")
+
+	ErrShortSharedSecretBuffer = errors.New("ecdh25519: shared secret buffer too short")
 )
 
 // PublicKey is the type of ecdh25519 public keys.
+//
+// PublicKey and PrivateKey are distinct defined types, so passing a value of
+// one where the other is expected does not compile without an explicit
+// conversion. Values of the unnamed type []byte are assignable to both, though,
+// so keys still held as plain []byte, for example straight from
+// hex.DecodeString, can be swapped without a compile error. Convert them to
+// PublicKey or PrivateKey as soon as they are decoded.
 type PublicKey []byte
 
 // PrivateKey is the type of ecdh25519 private keys.
 type PrivateKey []byte
 
+// KeyPair is an ecdh25519 public/private key pair.
+type KeyPair struct {
+	PublicKey  PublicKey
+	PrivateKey PrivateKey
+}
+
+// Equal reports whether p and other are the same public key, in constant time.
+// Keys of different lengths are never equal.
+func (p PublicKey) Equal(other PublicKey) bool {
+	return subtle.ConstantTimeCompare(p, other) == 1
+}
+
+// Equal reports whether p and other are the same private key, in constant time.
+// Keys of different lengths are never equal.
+func (p PrivateKey) Equal(other PrivateKey) bool {
+	return subtle.ConstantTimeCompare(p, other) == 1
+}
+
+// Zeroize overwrites the private key with zeros, so it does not linger in memory
+// once it is no longer needed. The Go runtime may still have copied the key
+// elsewhere, so this reduces rather than eliminates the exposure.
+func (p PrivateKey) Zeroize() {
+	zeroize(p)
+}
+
 // PublicKey returns the PublicKey corresponding to the PrivateKey.
+//
+// Like GenerateSharedSecret, it runs in constant time with respect to the
+// private key: curve25519.ScalarBaseMult uses a fixed-length Montgomery ladder
+// with constant-time conditional swaps on every input, including the base
+// point. The key is written straight into the returned slice.
+//
+// It returns ErrBadPrivateKeyLength if p is nil or not PrivateKeySize bytes long.
 func (p PrivateKey) PublicKey() (PublicKey, error) {
-	return curve25519.X25519(p, curve25519.Basepoint)
+	if l := len(p); l != PrivateKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	var scalar [32]byte
+	copy(scalar[:], p)
+	defer zeroize(scalar[:])
+
+	publicKey := make(PublicKey, PublicKeySize)
+	curve25519.ScalarBaseMult((*[32]byte)(publicKey), &scalar)
+
+	return publicKey, nil
+}
+
+// Public returns the public key corresponding to p, like the method of the same
+// name on crypto.Signer and the standard library private keys, so that key
+// stores can handle ecdh25519 keys generically. The dynamic type of the result
+// is PublicKey. It returns nil if p is malformed.
+func (p PrivateKey) Public() crypto.PublicKey {
+	publicKey, err := p.PublicKey()
+	if err != nil {
+		return nil
+	}
+
+	return publicKey
+}
+
+// MatchesPublicKey reports whether publicKey is the public key corresponding
+// to p, comparing them in constant time. It guards against importing a
+// mismatched key pair, which would silently produce useless shared secrets.
+func (p PrivateKey) MatchesPublicKey(publicKey PublicKey) (bool, error) {
+	if l := len(p); l != PrivateKeySize {
+		return false, fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	if l := len(publicKey); l != PublicKeySize {
+		return false, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	derived, err := p.PublicKey()
+	if err != nil {
+		return false, err
+	}
+
+	return derived.Equal(publicKey), nil
+}
+
+// ScalarMult returns the scalar multiplication of point by scalar, both encoded
+// as 32-byte little-endian strings as described in RFC 7748, section 5. The
+// scalar is clamped before use. It returns ErrBadPrivateKeyLength or
+// ErrBadPublicKeyLength if scalar or point has the wrong length.
+func ScalarMult(scalar, point []byte) ([]byte, error) {
+	if l := len(scalar); l != PrivateKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	if l := len(point); l != PublicKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	return curve25519.X25519(scalar, point)
 }
 
 // GenerateKeyPair generates a public/private key pair using entropy from rand.
 // If rand is nil, crypto/rand.Reader will be used.
+//
+// A read that comes up short is retried a few times, to ride out flaky
+// hardware sources. If every attempt is short, the returned error wraps both
+// ErrShortEntropyRead and the error of the last read.
 func GenerateKeyPair(rand io.Reader) (PublicKey, PrivateKey, error) {
+	return generateKeyPair(rand, false)
+}
+
+// GenerateKeyPairStrict is like GenerateKeyPair, but it also runs a sanity check
+// on the bytes read from rand and returns ErrSuspiciousEntropy if every byte is
+// the same, or if they have an implausibly low or high Hamming weight. This is a
+// heuristic to catch broken readers that return constant output, not a
+// statistical randomness test.
+func GenerateKeyPairStrict(rand io.Reader) (PublicKey, PrivateKey, error) {
+	return generateKeyPair(rand, true)
+}
+
+func generateKeyPair(rand io.Reader, strict bool) (PublicKey, PrivateKey, error) {
+	privateKey, err := generatePrivateKey(rand, strict)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	publicKey, err := privateKey.PublicKey()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return publicKey, privateKey, nil
+}
+
+// GeneratePrivateKey is like GenerateKeyPair, but it does not derive the public
+// key, saving a scalar multiplication when pre-generating many keys. The public
+// key can be derived later with PrivateKey.PublicKey.
+func GeneratePrivateKey(rand io.Reader) (PrivateKey, error) {
+	return generatePrivateKey(rand, false)
+}
+
+func generatePrivateKey(rand io.Reader, strict bool) (PrivateKey, error) {
 	if rand == nil {
 		rand = cryptorand.Reader
 	}
 
 	privateKey := make(PrivateKey, PrivateKeySize)
-	if _, err := io.ReadFull(rand, privateKey); err != nil {
-		return nil, nil, err
+	if err := readEntropy(rand, privateKey); err != nil {
+		return nil, err
+	}
+
+	if strict && suspiciousEntropy(privateKey) {
+		return nil, ErrSuspiciousEntropy
 	}
 
-	privateKey[0] &= 248
-	privateKey[31] &= 127
-	privateKey[31] |= 64
+	clamp(privateKey)
+
+	return privateKey, nil
+}
+
+// readEntropy fills b from rand, retrying short reads up to
+// entropyReadAttempts times in total. Other errors are returned as is.
+func readEntropy(rand io.Reader, b []byte) error {
+	var err error
+	for i := 0; i < entropyReadAttempts; i++ {
+		if _, err = io.ReadFull(rand, b); err == nil {
+			return nil
+		}
+
+		if err != io.ErrUnexpectedEOF && err != io.EOF {
+			return err
+		}
+	}
+
+	return fmt.Errorf("%w after %d attempts: %w", ErrShortEntropyRead, entropyReadAttempts, err)
+}
+
+// GenerateKeyPairMixed generates a public/private key pair using entropy mixed
+// from several sources, so that no single source alone determines the key.
+// It reads 32 bytes from each source in order and hashes them together with
+// SHA-256 to form the private key before clamping.
+func GenerateKeyPairMixed(sources ...io.Reader) (PublicKey, PrivateKey, error) {
+	if len(sources) == 0 {
+		return nil, nil, ErrNoEntropySources
+	}
+
+	h := sha256.New()
+	buf := make([]byte, PrivateKeySize)
+	for _, source := range sources {
+		if _, err := io.ReadFull(source, buf); err != nil {
+			return nil, nil, err
+		}
+
+		h.Write(buf)
+	}
+
+	return NewPrivateKeyFromSeed(h.Sum(nil))
+}
+
+// NewPrivateKeyFromSeed deterministically derives a public/private key pair
+// from a 32-byte seed. The private key is the seed with the clamping from
+// RFC 7748, section 5, applied; seed itself is not modified.
+func NewPrivateKeyFromSeed(seed []byte) (PublicKey, PrivateKey, error) {
+	if l := len(seed); l != SeedSize {
+		return nil, nil, fmt.Errorf("%w: %d", ErrBadSeedLength, l)
+	}
+
+	privateKey := make(PrivateKey, PrivateKeySize)
+	copy(privateKey, seed)
+	clamp(privateKey)
 
 	publicKey, err := privateKey.PublicKey()
 	if err != nil {
@@ -58,8 +270,32 @@ func GenerateKeyPair(rand io.Reader) (PublicKey, PrivateKey, error) {
 	return publicKey, privateKey, nil
 }
 
+// NewPrivateKey returns a copy of raw with the clamping from RFC 7748, section
+// 5, applied, to normalize private keys imported from sources that did not
+// clamp them. Clamping does not change the shared secrets the key computes.
+// raw itself is not modified.
+func NewPrivateKey(raw []byte) (PrivateKey, error) {
+	if l := len(raw); l != PrivateKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	privateKey := make(PrivateKey, PrivateKeySize)
+	copy(privateKey, raw)
+	clamp(privateKey)
+
+	return privateKey, nil
+}
+
+// IsClamped reports whether p has the right length and is clamped as described
+// in RFC 7748, section 5, as keys generated by this package are.
+func (p PrivateKey) IsClamped() bool {
+	return len(p) == PrivateKeySize && p[0]&7 == 0 && p[31]&0xc0 == 0x40
+}
+
 // GenerateSharedSecret generates a shared secret by using someone else's public key.
-func GenerateSharedSecret(privateKey PrivateKey, publicKey PublicKey) ([]byte, error) {
+// It returns ErrLowOrderPoint if publicKey is a low-order point, which would
+// force an all-zero shared secret.
+func GenerateSharedSecret(privateKey PrivateKey, publicKey PublicKey) (SharedSecret, error) {
 	if l := len(privateKey); l != PrivateKeySize {
 		return nil, fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
 	}
@@ -68,5 +304,94 @@ func GenerateSharedSecret(privateKey PrivateKey, publicKey PublicKey) ([]byte, e
 		return nil, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
 	}
 
-	return curve25519.X25519(privateKey, publicKey)
+	return scalarMultChecked(privateKey, publicKey)
+}
+
+// GenerateSharedSecretInto is like GenerateSharedSecret, but it writes the
+// shared secret into the first 32 bytes of dst, so callers can keep the secret
+// in a buffer they own and reuse. This saves only the allocation of the
+// returned slice: the X25519 computation itself still allocates a temporary
+// result, which is zeroized after being copied. It returns
+// ErrShortSharedSecretBuffer if dst is shorter than SharedSecretSize. dst is
+// not modified on error.
+func GenerateSharedSecretInto(dst []byte, privateKey PrivateKey, publicKey PublicKey) error {
+	if l := len(dst); l < SharedSecretSize {
+		return fmt.Errorf("%w: %d", ErrShortSharedSecretBuffer, l)
+	}
+
+	if l := len(privateKey); l != PrivateKeySize {
+		return fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	if l := len(publicKey); l != PublicKeySize {
+		return fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	return scalarMultInto(dst, privateKey, publicKey)
+}
+
+// scalarMultChecked returns privateKey * publicKey, rejecting the all-zero
+// output produced by low-order points as recommended by RFC 7748, section 6.1.
+// Both inputs must already have the right length.
+func scalarMultChecked(privateKey PrivateKey, publicKey PublicKey) (SharedSecret, error) {
+	sharedSecret := make(SharedSecret, SharedSecretSize)
+	if err := scalarMultInto(sharedSecret, privateKey, publicKey); err != nil {
+		return nil, err
+	}
+
+	return sharedSecret, nil
+}
+
+// scalarMultInto is like scalarMultChecked, but it writes the result into dst,
+// which must be at least SharedSecretSize bytes long.
+func scalarMultInto(dst []byte, privateKey PrivateKey, publicKey PublicKey) error {
+	// With inputs of the right length, X25519 only fails on the all-zero
+	// output of a low-order point.
+	sharedSecret, err := curve25519.X25519(privateKey, publicKey)
+	if err != nil {
+		return ErrLowOrderPoint
+	}
+
+	copy(dst, sharedSecret)
+	zeroize(sharedSecret)
+
+	return nil
+}
+
+// GenerateSharedSecretConsuming is like GenerateSharedSecret, but it consumes
+// publicKey: the slice is zeroized before returning, whether or not the
+// computation succeeds. This shortens the lifetime of handshake material
+// received from the network when the caller hands over ownership of it.
+func GenerateSharedSecretConsuming(privateKey PrivateKey, publicKey PublicKey) (SharedSecret, error) {
+	defer zeroize(publicKey)
+
+	return GenerateSharedSecret(privateKey, publicKey)
+}
+
+// suspiciousEntropy reports whether b is very unlikely to come from a working
+// entropy source: either every byte is the same, as from a reader stuck on a
+// constant, or its Hamming weight is far from half its bits.
+func suspiciousEntropy(b []byte) bool {
+	weight := 0
+	constant := true
+	for _, v := range b {
+		weight += bits.OnesCount8(v)
+		constant = constant && v == b[0]
+	}
+
+	return constant || weight < len(b)*2 || weight > len(b)*6
+}
+
+// clamp applies the scalar clamping described in RFC 7748, section 5, in place.
+func clamp(k []byte) {
+	k[0] &= 248
+	k[31] &= 127
+	k[31] |= 64
+}
+
+// zeroize overwrites b with zeros.
+func zeroize(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
 }
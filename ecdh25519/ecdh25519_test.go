@@ -2,18 +2,25 @@ package ecdh25519_test
 
 import (
 	"bytes"
+	"crypto"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"reflect"
 	"testing"
+	"testing/iotest"
 
 	"github.com/adnsio/ecdh/ecdh25519"
+	"github.com/adnsio/ecdh/ecdh25519/ecdhtest"
 )
 
 // test vectors from: https://www.ietf.org/rfc/rfc7748.html#section-6.1
 
+var long = flag.Bool("long", false, "run long-running tests")
+
 func TestGenerateKeyPair(t *testing.T) {
 	type args struct {
 		rand io.Reader
@@ -43,6 +50,306 @@ func TestGenerateKeyPair(t *testing.T) {
 	}
 }
 
+func TestGeneratePrivateKey(t *testing.T) {
+	privateKey, err := ecdh25519.GeneratePrivateKey(ecdhtest.DeterministicReader([]byte("seed")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !privateKey.IsClamped() {
+		t.Errorf("GeneratePrivateKey() = %x, want a clamped key", []byte(privateKey))
+	}
+
+	_, want, err := ecdh25519.GenerateKeyPair(ecdhtest.DeterministicReader([]byte("seed")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(privateKey, want) {
+		t.Errorf("GeneratePrivateKey() = %x, want the GenerateKeyPair key %x", []byte(privateKey), []byte(want))
+	}
+
+	if _, err := ecdh25519.GeneratePrivateKey(bytes.NewReader(make([]byte, 16))); !errors.Is(err, ecdh25519.ErrShortEntropyRead) {
+		t.Errorf("GeneratePrivateKey() error = %v, want %v", err, ecdh25519.ErrShortEntropyRead)
+	}
+}
+
+func BenchmarkGeneratePrivateKey(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		privateKey, err := ecdh25519.GeneratePrivateKey(rand.Reader)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		benchmarkSink ^= privateKey[0]
+	}
+}
+
+// flakyReader returns a short read for its first failures reads, then reads
+// from crypto/rand.
+type flakyReader struct {
+	failures int
+}
+
+func (r *flakyReader) Read(p []byte) (int, error) {
+	if r.failures > 0 {
+		r.failures--
+		return copy(p, []byte{0x42}), io.EOF
+	}
+
+	return rand.Read(p)
+}
+
+func TestGenerateKeyPair_retry(t *testing.T) {
+	errBroken := errors.New("broken")
+
+	tests := []struct {
+		name    string
+		rand    io.Reader
+		wantErr []error
+	}{
+		{
+			name: "with two short reads",
+			rand: &flakyReader{failures: 2},
+		},
+		{
+			name:    "with three short reads",
+			rand:    &flakyReader{failures: 3},
+			wantErr: []error{ecdh25519.ErrShortEntropyRead, io.ErrUnexpectedEOF},
+		},
+		{
+			name:    "with empty reader",
+			rand:    bytes.NewReader(nil),
+			wantErr: []error{ecdh25519.ErrShortEntropyRead, io.EOF},
+		},
+		{
+			name:    "with failing reader",
+			rand:    iotest.ErrReader(errBroken),
+			wantErr: []error{errBroken},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ecdh25519.GenerateKeyPair(tt.rand)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("GenerateKeyPair() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			for _, wantErr := range tt.wantErr {
+				if !errors.Is(err, wantErr) {
+					t.Errorf("GenerateKeyPair() error = %v, want %v", err, wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateKeyPairStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		rand    io.Reader
+		wantErr error
+	}{
+		{
+			name: "with crypto rand",
+			rand: rand.Reader,
+		},
+		{
+			name:    "with zero reader",
+			rand:    bytes.NewReader(make([]byte, 32)),
+			wantErr: ecdh25519.ErrSuspiciousEntropy,
+		},
+		{
+			name:    "with all ones reader",
+			rand:    bytes.NewReader(bytes.Repeat([]byte{0xff}, 32)),
+			wantErr: ecdh25519.ErrSuspiciousEntropy,
+		},
+		{
+			name:    "with low hamming weight reader",
+			rand:    bytes.NewReader(bytes.Repeat([]byte{0x01}, 32)),
+			wantErr: ecdh25519.ErrSuspiciousEntropy,
+		},
+		{
+			name:    "with constant reader",
+			rand:    bytes.NewReader(bytes.Repeat([]byte{0x42}, 32)),
+			wantErr: ecdh25519.ErrSuspiciousEntropy,
+		},
+		{
+			name:    "with constant alternating bits reader",
+			rand:    bytes.NewReader(bytes.Repeat([]byte{0xaa}, 32)),
+			wantErr: ecdh25519.ErrSuspiciousEntropy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ecdh25519.GenerateKeyPairStrict(tt.rand)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GenerateKeyPairStrict() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerateKeyPairMixed(t *testing.T) {
+	first := bytes.Repeat([]byte{1}, 32)
+	second := bytes.Repeat([]byte{2}, 32)
+
+	publicKey, privateKey, err := ecdh25519.GenerateKeyPairMixed(bytes.NewReader(first), bytes.NewReader(second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	againPublicKey, againPrivateKey, err := ecdh25519.GenerateKeyPairMixed(bytes.NewReader(first), bytes.NewReader(second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(privateKey, againPrivateKey) || !reflect.DeepEqual(publicKey, againPublicKey) {
+		t.Errorf("GenerateKeyPairMixed() is not deterministic")
+	}
+
+	for _, source := range [][]byte{first, second} {
+		_, singlePrivateKey, err := ecdh25519.GenerateKeyPair(bytes.NewReader(source))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if reflect.DeepEqual(privateKey, singlePrivateKey) {
+			t.Errorf("GenerateKeyPairMixed() = %v, determined by a single source", privateKey)
+		}
+	}
+
+	if _, _, err := ecdh25519.GenerateKeyPairMixed(bytes.NewReader(first), bytes.NewReader(second[:16])); err == nil {
+		t.Errorf("GenerateKeyPairMixed() with a short source error = nil, want error")
+	}
+
+	if _, _, err := ecdh25519.GenerateKeyPairMixed(); !errors.Is(err, ecdh25519.ErrNoEntropySources) {
+		t.Errorf("GenerateKeyPairMixed() error = %v, want %v", err, ecdh25519.ErrNoEntropySources)
+	}
+}
+
+func TestNewPrivateKeyFromSeed(t *testing.T) {
+	seed := bytes.Repeat([]byte{0xff}, ecdh25519.SeedSize)
+
+	publicKey, privateKey, err := ecdh25519.NewPrivateKeyFromSeed(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantPrivateKey := ecdh25519.PrivateKey(bytes.Repeat([]byte{0xff}, ecdh25519.PrivateKeySize))
+	wantPrivateKey[0] = 0xf8
+	wantPrivateKey[31] = 0x7f
+
+	if !reflect.DeepEqual(privateKey, wantPrivateKey) {
+		t.Errorf("NewPrivateKeyFromSeed() private key = %x, want %x", privateKey, wantPrivateKey)
+	}
+
+	wantPublicKey, err := wantPrivateKey.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(publicKey, wantPublicKey) {
+		t.Errorf("NewPrivateKeyFromSeed() public key = %x, want %x", publicKey, wantPublicKey)
+	}
+
+	if !bytes.Equal(seed, bytes.Repeat([]byte{0xff}, ecdh25519.SeedSize)) {
+		t.Errorf("NewPrivateKeyFromSeed() modified the seed")
+	}
+
+	_, generatedPrivateKey, err := ecdh25519.GenerateKeyPair(bytes.NewReader(seed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(privateKey, generatedPrivateKey) {
+		t.Errorf("NewPrivateKeyFromSeed() = %x, GenerateKeyPair() = %x", privateKey, generatedPrivateKey)
+	}
+
+	for _, l := range []int{0, 31, 33} {
+		if _, _, err := ecdh25519.NewPrivateKeyFromSeed(make([]byte, l)); !errors.Is(err, ecdh25519.ErrBadSeedLength) {
+			t.Errorf("NewPrivateKeyFromSeed() with %d bytes error = %v, want %v", l, err, ecdh25519.ErrBadSeedLength)
+		}
+	}
+}
+
+func TestNewPrivateKey(t *testing.T) {
+	raw := bytes.Repeat([]byte{0xff}, ecdh25519.PrivateKeySize)
+
+	privateKey, err := ecdh25519.NewPrivateKey(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !privateKey.IsClamped() {
+		t.Errorf("NewPrivateKey() = %x, want a clamped key", []byte(privateKey))
+	}
+
+	if ecdh25519.PrivateKey(raw).IsClamped() || raw[0] != 0xff {
+		t.Errorf("NewPrivateKey() modified its input")
+	}
+
+	if _, err := ecdh25519.NewPrivateKey(raw[:16]); !errors.Is(err, ecdh25519.ErrBadPrivateKeyLength) {
+		t.Errorf("NewPrivateKey() error = %v, want %v", err, ecdh25519.ErrBadPrivateKeyLength)
+	}
+
+	// X25519 clamps internally, so normalizing does not change the public key.
+	want, err := ecdh25519.PrivateKey(raw).PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := privateKey.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !got.Equal(want) {
+		t.Errorf("NewPrivateKey() public key = %v, want %v", got, want)
+	}
+}
+
+func TestPrivateKey_IsClamped(t *testing.T) {
+	_, generated, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		p    ecdh25519.PrivateKey
+		want bool
+	}{
+		{
+			name: "generated",
+			p:    generated,
+			want: true,
+		},
+		{
+			name: "all zero",
+			p:    make([]byte, ecdh25519.PrivateKeySize),
+		},
+		{
+			name: "all ones",
+			p:    bytes.Repeat([]byte{0xff}, ecdh25519.PrivateKeySize),
+		},
+		{
+			name: "short",
+			p:    generated[:16],
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.IsClamped(); got != tt.want {
+				t.Errorf("PrivateKey.IsClamped() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestGenerateSharedSecret(t *testing.T) {
 	type args struct {
 		privateKey ecdh25519.PrivateKey
@@ -77,7 +384,7 @@ func TestGenerateSharedSecret(t *testing.T) {
 	tests := []struct {
 		name    string
 		args    args
-		want    []byte
+		want    ecdh25519.SharedSecret
 		wantErr bool
 	}{
 		{
@@ -113,6 +420,142 @@ func TestGenerateSharedSecret(t *testing.T) {
 	}
 }
 
+func TestGenerateSharedSecretInto(t *testing.T) {
+	alicePublicKey, alicePrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bobPublicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := ecdh25519.GenerateSharedSecret(alicePrivateKey, bobPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst := make([]byte, ecdh25519.SharedSecretSize+1)
+	if err := ecdh25519.GenerateSharedSecretInto(dst, alicePrivateKey, bobPublicKey); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(dst[:ecdh25519.SharedSecretSize], want.Bytes()) || dst[ecdh25519.SharedSecretSize] != 0 {
+		t.Errorf("GenerateSharedSecretInto() = %v, want %v", dst, want)
+	}
+
+	tests := []struct {
+		name       string
+		dst        []byte
+		privateKey ecdh25519.PrivateKey
+		publicKey  ecdh25519.PublicKey
+		wantErr    error
+	}{
+		{
+			name:       "with short buffer",
+			dst:        make([]byte, 16),
+			privateKey: alicePrivateKey,
+			publicKey:  bobPublicKey,
+			wantErr:    ecdh25519.ErrShortSharedSecretBuffer,
+		},
+		{
+			name:       "with short public key",
+			dst:        make([]byte, ecdh25519.SharedSecretSize),
+			privateKey: alicePrivateKey,
+			publicKey:  alicePublicKey[:16],
+			wantErr:    ecdh25519.ErrBadPublicKeyLength,
+		},
+		{
+			name:       "with low order public key",
+			dst:        make([]byte, ecdh25519.SharedSecretSize),
+			privateKey: alicePrivateKey,
+			publicKey:  make([]byte, ecdh25519.PublicKeySize),
+			wantErr:    ecdh25519.ErrLowOrderPoint,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ecdh25519.GenerateSharedSecretInto(tt.dst, tt.privateKey, tt.publicKey); !errors.Is(err, tt.wantErr) {
+				t.Errorf("GenerateSharedSecretInto() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerateSharedSecret_lowOrder(t *testing.T) {
+	_, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		publicKey string
+	}{
+		{
+			name:      "with zero",
+			publicKey: "0000000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:      "with one",
+			publicKey: "0100000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:      "with order 8 point",
+			publicKey: "e0eb7a7c3b41b8ae1656e3faf19fc46ada098deb9c32b1fd866205165f49b800",
+		},
+		{
+			name:      "with p-1",
+			publicKey: "ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publicKey, err := hex.DecodeString(tt.publicKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := ecdh25519.GenerateSharedSecret(privateKey, publicKey); !errors.Is(err, ecdh25519.ErrLowOrderPoint) {
+				t.Errorf("GenerateSharedSecret() error = %v, want %v", err, ecdh25519.ErrLowOrderPoint)
+			}
+		})
+	}
+}
+
+func TestGenerateSharedSecretConsuming(t *testing.T) {
+	alicePublicKey, alicePrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bobPublicKey, bobPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := ecdh25519.GenerateSharedSecret(bobPrivateKey, alicePublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ecdh25519.GenerateSharedSecretConsuming(alicePrivateKey, bobPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GenerateSharedSecretConsuming() = %v, want %v", got, want)
+	}
+
+	if !bytes.Equal(bobPublicKey, make([]byte, ecdh25519.PublicKeySize)) {
+		t.Errorf("GenerateSharedSecretConsuming() left public key = %v, want zeroed", bobPublicKey)
+	}
+}
+
 func TestPrivateKey_PublicKey(t *testing.T) {
 	alicePrivateKey, err := hex.DecodeString("77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a")
 	if err != nil {
@@ -138,7 +581,7 @@ func TestPrivateKey_PublicKey(t *testing.T) {
 		name    string
 		p       ecdh25519.PrivateKey
 		want    ecdh25519.PublicKey
-		wantErr bool
+		wantErr error
 	}{
 		{
 			name: "alice public",
@@ -150,12 +593,22 @@ func TestPrivateKey_PublicKey(t *testing.T) {
 			p:    bobPrivateKey,
 			want: bobPublicKey,
 		},
+		{
+			name:    "with nil key",
+			p:       nil,
+			wantErr: ecdh25519.ErrBadPrivateKeyLength,
+		},
+		{
+			name:    "with short key",
+			p:       alicePrivateKey[:16],
+			wantErr: ecdh25519.ErrBadPrivateKeyLength,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := tt.p.PublicKey()
-			if (err != nil) != tt.wantErr {
+			if !errors.Is(err, tt.wantErr) {
 				t.Errorf("PrivateKey.PublicKey() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
@@ -167,10 +620,211 @@ func TestPrivateKey_PublicKey(t *testing.T) {
 	}
 }
 
+func TestPrivateKey_Public(t *testing.T) {
+	publicKey, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var key crypto.PrivateKey = privateKey
+	signerLike, ok := key.(interface{ Public() crypto.PublicKey })
+	if !ok {
+		t.Fatalf("PrivateKey does not implement Public() crypto.PublicKey")
+	}
+
+	got, ok := signerLike.Public().(ecdh25519.PublicKey)
+	if !ok || !reflect.DeepEqual(got, publicKey) {
+		t.Errorf("PrivateKey.Public() = %v, want %v", got, publicKey)
+	}
+
+	if got := ecdh25519.PrivateKey(make([]byte, 16)).Public(); got != nil {
+		t.Errorf("PrivateKey.Public() with short key = %v, want nil", got)
+	}
+}
+
+func TestPrivateKey_MatchesPublicKey(t *testing.T) {
+	publicKey, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherPublicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		privateKey ecdh25519.PrivateKey
+		publicKey  ecdh25519.PublicKey
+		want       bool
+		wantErr    error
+	}{
+		{
+			name:       "matching",
+			privateKey: privateKey,
+			publicKey:  publicKey,
+			want:       true,
+		},
+		{
+			name:       "mismatched",
+			privateKey: privateKey,
+			publicKey:  otherPublicKey,
+		},
+		{
+			name:       "with short private key",
+			privateKey: privateKey[:16],
+			publicKey:  publicKey,
+			wantErr:    ecdh25519.ErrBadPrivateKeyLength,
+		},
+		{
+			name:       "with short public key",
+			privateKey: privateKey,
+			publicKey:  publicKey[:16],
+			wantErr:    ecdh25519.ErrBadPublicKeyLength,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.privateKey.MatchesPublicKey(tt.publicKey)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PrivateKey.MatchesPublicKey() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("PrivateKey.MatchesPublicKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// iterated test vectors from: https://www.ietf.org/rfc/rfc7748.html#section-5.2
+func TestScalarMult_iterated(t *testing.T) {
+	tests := []struct {
+		name       string
+		iterations int
+		want       string
+		long       bool
+	}{
+		{
+			name:       "1 iteration",
+			iterations: 1,
+			want:       "422c8e7a6227d7bca1350b3e2bb7279f7897b87bb6854b783c60e80311ae3079",
+		},
+		{
+			name:       "1000 iterations",
+			iterations: 1000,
+			want:       "684cf59ba83309552800ef566f2f4d3c1c3887c49360e3875f2eb94d99532c51",
+		},
+		{
+			name:       "1000000 iterations",
+			iterations: 1000000,
+			want:       "7c3911e0ab2586fd864497297e575e6f3bc601c0883c30df5f4dd2d24f665424",
+			long:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.long && !*long {
+				t.Skip("skipping long test, run with -long to enable")
+			}
+
+			k := make([]byte, 32)
+			k[0] = 9
+			u := make([]byte, 32)
+			u[0] = 9
+
+			for i := 0; i < tt.iterations; i++ {
+				r, err := ecdh25519.ScalarMult(k, u)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				k, u = r, k
+			}
+
+			if got := hex.EncodeToString(k); got != tt.want {
+				t.Errorf("ScalarMult() after %d iterations = %v, want %v", tt.iterations, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublicKey_Equal(t *testing.T) {
+	publicKey, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherPublicKey, otherPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicKeyTests := []struct {
+		name  string
+		p     ecdh25519.PublicKey
+		other ecdh25519.PublicKey
+		want  bool
+	}{
+		{name: "with same key", p: publicKey, other: append(ecdh25519.PublicKey(nil), publicKey...), want: true},
+		{name: "with other key", p: publicKey, other: otherPublicKey},
+		{name: "with short key", p: publicKey, other: publicKey[:16]},
+		{name: "with nil key", p: publicKey, other: nil},
+	}
+
+	for _, tt := range publicKeyTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.Equal(tt.other); got != tt.want {
+				t.Errorf("PublicKey.Equal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	privateKeyTests := []struct {
+		name  string
+		p     ecdh25519.PrivateKey
+		other ecdh25519.PrivateKey
+		want  bool
+	}{
+		{name: "with same key", p: privateKey, other: append(ecdh25519.PrivateKey(nil), privateKey...), want: true},
+		{name: "with other key", p: privateKey, other: otherPrivateKey},
+		{name: "with short key", p: privateKey, other: privateKey[:16]},
+		{name: "with nil key", p: privateKey, other: nil},
+	}
+
+	for _, tt := range privateKeyTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.Equal(tt.other); got != tt.want {
+				t.Errorf("PrivateKey.Equal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrivateKey_Zeroize(t *testing.T) {
+	_, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privateKey.Zeroize()
+
+	if !bytes.Equal(privateKey, make([]byte, ecdh25519.PrivateKeySize)) {
+		t.Errorf("PrivateKey.Zeroize() left %v, want zeroed", privateKey)
+	}
+
+	var nilPrivateKey ecdh25519.PrivateKey
+	nilPrivateKey.Zeroize()
+}
+
 var benchmarkSink byte
 
 func BenchmarkGenerateKeyPair(b *testing.B) {
-	b.ResetTimer()
+	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
 		publicKey, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
 		if err != nil {
@@ -182,6 +836,140 @@ func BenchmarkGenerateKeyPair(b *testing.B) {
 	}
 }
 
+// timingScalars are private keys at the extremes of Hamming weight, to show that
+// the per-operation timing does not depend on the key bits.
+var timingScalars = []struct {
+	name string
+	key  ecdh25519.PrivateKey
+}{
+	{name: "low-weight", key: bytes.Repeat([]byte{0x00}, ecdh25519.PrivateKeySize)},
+	{name: "high-weight", key: bytes.Repeat([]byte{0xff}, ecdh25519.PrivateKeySize)},
+	{name: "alternating", key: bytes.Repeat([]byte{0xaa}, ecdh25519.PrivateKeySize)},
+}
+
+func BenchmarkPublicKey(b *testing.B) {
+	for _, s := range timingScalars {
+		b.Run(s.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				publicKey, err := s.key.PublicKey()
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				benchmarkSink ^= publicKey[0]
+			}
+		})
+	}
+}
+
+func BenchmarkGenerateSharedSecret(b *testing.B) {
+	publicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, s := range timingScalars {
+		b.Run(s.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				sharedSecret, err := ecdh25519.GenerateSharedSecret(s.key, publicKey)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				benchmarkSink ^= sharedSecret[0]
+			}
+		})
+	}
+}
+
+// BenchmarkSharedSecret compares the allocating shared secret API with the one
+// writing into a caller-provided buffer.
+func BenchmarkSharedSecret(b *testing.B) {
+	publicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	_, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("alloc", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sharedSecret, err := ecdh25519.GenerateSharedSecret(privateKey, publicKey)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			benchmarkSink ^= sharedSecret[0]
+		}
+	})
+
+	b.Run("into", func(b *testing.B) {
+		b.ReportAllocs()
+		sharedSecret := make([]byte, ecdh25519.SharedSecretSize)
+		for i := 0; i < b.N; i++ {
+			if err := ecdh25519.GenerateSharedSecretInto(sharedSecret, privateKey, publicKey); err != nil {
+				b.Fatal(err)
+			}
+
+			benchmarkSink ^= sharedSecret[0]
+		}
+	})
+}
+
+// BenchmarkExchange runs a full key exchange end to end: both sides generate a
+// key pair and compute the shared secret.
+func BenchmarkExchange(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		alicePublicKey, alicePrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		bobPublicKey, bobPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		aliceSharedSecret, err := ecdh25519.GenerateSharedSecret(alicePrivateKey, bobPublicKey)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		bobSharedSecret, err := ecdh25519.GenerateSharedSecret(bobPrivateKey, alicePublicKey)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		benchmarkSink ^= aliceSharedSecret[0] ^ bobSharedSecret[0]
+	}
+}
+
+// TestAllocs checks the allocations this package controls. Most of them
+// happen in crypto/ecdh and vary with the toolchain, so only the buffer that
+// GenerateSharedSecretInto saves is asserted.
+func TestAllocs(t *testing.T) {
+	publicKey, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sharedSecret := make([]byte, ecdh25519.SharedSecretSize)
+
+	allocs := testing.AllocsPerRun(10, func() { _, _ = ecdh25519.GenerateSharedSecret(privateKey, publicKey) })
+	intoAllocs := testing.AllocsPerRun(10, func() { _ = ecdh25519.GenerateSharedSecretInto(sharedSecret, privateKey, publicKey) })
+
+	if intoAllocs >= allocs {
+		t.Errorf("GenerateSharedSecretInto() allocations = %v, want fewer than GenerateSharedSecret() %v", intoAllocs, allocs)
+	}
+}
+
 func ExampleGenerateKeyPair() {
 	alicePublicKey, alicePrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
 	if err != nil {
@@ -0,0 +1,86 @@
+// Package ecdhtest provides helpers for testing code that uses ecdh25519.
+// It is meant for test suites only and must not be used in production.
+package ecdhtest
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+// minDistinctBytes is the smallest number of distinct byte values accepted in a
+// private key. A uniformly random 32-byte key has about 30 on average.
+const minDistinctBytes = 16
+
+// knownPrivateKeys are fixed private keys that show up in tests, such as the
+// RFC 7748 section 6.1 vectors. They are compared in clamped form, so keys that
+// went through NewPrivateKey or were re-derived are caught as well.
+var knownPrivateKeys = []string{
+	AlicePrivateKeyHex,
+	BobPrivateKeyHex,
+}
+
+// AssertRandomKey reports a test error if privateKey does not look like it was
+// generated from a proper entropy source. It flags keys of the wrong length,
+// keys read from a constant reader (such as an all-zero one), well-known test
+// keys, and keys with too few distinct byte values.
+//
+// This is a heuristic guardrail against accidentally using deterministic
+// readers, not a statistical randomness test.
+func AssertRandomKey(t testing.TB, privateKey ecdh25519.PrivateKey) {
+	t.Helper()
+
+	if l := len(privateKey); l != ecdh25519.PrivateKeySize {
+		t.Errorf("ecdhtest: bad private key length: %d", l)
+		return
+	}
+
+	if isConstant(privateKey) {
+		t.Errorf("ecdhtest: private key was generated from a constant reader: %x", []byte(privateKey))
+		return
+	}
+
+	for _, known := range knownPrivateKeys {
+		knownPrivateKey, err := hex.DecodeString(known)
+		if err != nil {
+			panic(err)
+		}
+
+		if bytes.Equal(clamped(privateKey), clamped(knownPrivateKey)) {
+			t.Errorf("ecdhtest: private key is a well-known test key: %s", known)
+			return
+		}
+	}
+
+	seen := make(map[byte]bool)
+	for _, b := range privateKey {
+		seen[b] = true
+	}
+
+	if len(seen) < minDistinctBytes {
+		t.Errorf("ecdhtest: private key has low entropy (%d distinct bytes): %x", len(seen), []byte(privateKey))
+	}
+}
+
+// clamped returns a copy of k clamped as described in RFC 7748, section 5.
+func clamped(k []byte) []byte {
+	c := append([]byte(nil), k...)
+	c[0] &= 248
+	c[31] &= 127
+	c[31] |= 64
+
+	return c
+}
+
+// isConstant reports whether privateKey is the clamped form of a key whose
+// bytes are all equal.
+func isConstant(privateKey ecdh25519.PrivateKey) bool {
+	b := privateKey[1]
+	if !bytes.Equal(privateKey[1:31], bytes.Repeat([]byte{b}, 30)) {
+		return false
+	}
+
+	return privateKey[0] == b&248 && privateKey[31] == b&127|64
+}
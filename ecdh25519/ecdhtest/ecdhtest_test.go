@@ -0,0 +1,102 @@
+package ecdhtest_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+	"github.com/adnsio/ecdh/ecdh25519/ecdhtest"
+)
+
+// recorder is a testing.TB that records errors instead of failing the test.
+type recorder struct {
+	testing.TB
+	failed bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failed = true
+}
+
+func TestAssertRandomKey(t *testing.T) {
+	_, randomPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, zeroPrivateKey, err := ecdh25519.GenerateKeyPair(bytes.NewReader(make([]byte, 32)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, constantPrivateKey, err := ecdh25519.GenerateKeyPair(bytes.NewReader(bytes.Repeat([]byte{0xab}, 32)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alicePrivateKey, err := hex.DecodeString("77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clampedAlicePrivateKey, err := ecdh25519.NewPrivateKey(alicePrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		privateKey ecdh25519.PrivateKey
+		wantFail   bool
+	}{
+		{
+			name:       "with crypto rand",
+			privateKey: randomPrivateKey,
+		},
+		{
+			name:       "with zero reader",
+			privateKey: zeroPrivateKey,
+			wantFail:   true,
+		},
+		{
+			name:       "with constant reader",
+			privateKey: constantPrivateKey,
+			wantFail:   true,
+		},
+		{
+			name:       "with alice test key",
+			privateKey: alicePrivateKey,
+			wantFail:   true,
+		},
+		{
+			name:       "with clamped alice test key",
+			privateKey: clampedAlicePrivateKey,
+			wantFail:   true,
+		},
+		{
+			name:       "with low entropy",
+			privateKey: bytes.Repeat([]byte{0x10, 0x20, 0x30, 0x40}, 8),
+			wantFail:   true,
+		},
+		{
+			name:       "with bad length",
+			privateKey: randomPrivateKey[:16],
+			wantFail:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			ecdhtest.AssertRandomKey(r, tt.privateKey)
+
+			if r.failed != tt.wantFail {
+				t.Errorf("AssertRandomKey() failed = %v, wantFail %v", r.failed, tt.wantFail)
+			}
+		})
+	}
+}
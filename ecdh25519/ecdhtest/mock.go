@@ -0,0 +1,97 @@
+package ecdhtest
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+// Mock is a fake ecdh25519.DH for testing protocol code without real
+// cryptography. It is safe for concurrent use and its zero value is ready to use.
+//
+// Mock offers no security at all: its public keys are copies of the private
+// keys and its shared secrets are the XOR of both keys, so outputs are
+// deterministic and easy to inspect.
+type Mock struct {
+	mu    sync.Mutex
+	next  uint64
+	calls []string
+
+	// Err, if set, is returned by every operation, for fault injection.
+	Err error
+}
+
+var _ ecdh25519.DH = (*Mock)(nil)
+
+// GenerateKeyPair returns the next key pair in a fixed sequence. It ignores rand.
+func (m *Mock) GenerateKeyPair(rand io.Reader) (ecdh25519.PublicKey, ecdh25519.PrivateKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, "GenerateKeyPair")
+	if m.Err != nil {
+		return nil, nil, m.Err
+	}
+
+	sum := sha256.Sum256([]byte(fmt.Sprintf("ecdhtest mock key %d", m.next)))
+	m.next++
+
+	privateKey := ecdh25519.PrivateKey(sum[:])
+	publicKey := ecdh25519.PublicKey(append([]byte(nil), sum[:]...))
+
+	return publicKey, privateKey, nil
+}
+
+// SharedSecret returns the XOR of privateKey and publicKey.
+func (m *Mock) SharedSecret(privateKey ecdh25519.PrivateKey, publicKey ecdh25519.PublicKey) (ecdh25519.SharedSecret, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, "SharedSecret")
+	if m.Err != nil {
+		return nil, m.Err
+	}
+
+	if l := len(privateKey); l != ecdh25519.PrivateKeySize {
+		return nil, fmt.Errorf("%w: %d", ecdh25519.ErrBadPrivateKeyLength, l)
+	}
+
+	if l := len(publicKey); l != ecdh25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: %d", ecdh25519.ErrBadPublicKeyLength, l)
+	}
+
+	sharedSecret := make(ecdh25519.SharedSecret, ecdh25519.SharedSecretSize)
+	for i := range sharedSecret {
+		sharedSecret[i] = privateKey[i] ^ publicKey[i]
+	}
+
+	return sharedSecret, nil
+}
+
+// PublicKeyFromPrivate returns a copy of privateKey.
+func (m *Mock) PublicKeyFromPrivate(privateKey ecdh25519.PrivateKey) (ecdh25519.PublicKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, "PublicKeyFromPrivate")
+	if m.Err != nil {
+		return nil, m.Err
+	}
+
+	if l := len(privateKey); l != ecdh25519.PrivateKeySize {
+		return nil, fmt.Errorf("%w: %d", ecdh25519.ErrBadPrivateKeyLength, l)
+	}
+
+	return ecdh25519.PublicKey(append([]byte(nil), privateKey...)), nil
+}
+
+// Calls returns the names of the operations invoked so far, in order.
+func (m *Mock) Calls() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]string(nil), m.calls...)
+}
@@ -0,0 +1,99 @@
+package ecdhtest_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+	"github.com/adnsio/ecdh/ecdh25519/ecdhtest"
+)
+
+// agree is an example of protocol code written against ecdh25519.DH.
+func agree(dh ecdh25519.DH) (bool, error) {
+	alicePublicKey, alicePrivateKey, err := dh.GenerateKeyPair(nil)
+	if err != nil {
+		return false, err
+	}
+
+	bobPublicKey, bobPrivateKey, err := dh.GenerateKeyPair(nil)
+	if err != nil {
+		return false, err
+	}
+
+	aliceSharedSecret, err := dh.SharedSecret(alicePrivateKey, bobPublicKey)
+	if err != nil {
+		return false, err
+	}
+
+	bobSharedSecret, err := dh.SharedSecret(bobPrivateKey, alicePublicKey)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(aliceSharedSecret, bobSharedSecret), nil
+}
+
+func TestMock(t *testing.T) {
+	mock := &ecdhtest.Mock{}
+
+	ok, err := agree(mock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ok {
+		t.Errorf("Mock shared secrets do not agree")
+	}
+
+	want := []string{"GenerateKeyPair", "GenerateKeyPair", "SharedSecret", "SharedSecret"}
+	if got := mock.Calls(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Mock.Calls() = %v, want %v", got, want)
+	}
+
+	publicKey, _, err := (&ecdhtest.Mock{}).GenerateKeyPair(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	againPublicKey, _, err := (&ecdhtest.Mock{}).GenerateKeyPair(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(publicKey, againPublicKey) {
+		t.Errorf("Mock.GenerateKeyPair() is not deterministic")
+	}
+
+	errInjected := errors.New("injected")
+	if _, err := agree(&ecdhtest.Mock{Err: errInjected}); !errors.Is(err, errInjected) {
+		t.Errorf("agree() error = %v, want %v", err, errInjected)
+	}
+}
+
+func ExampleMock() {
+	// In production, protocol code runs with the real implementation.
+	ok, err := agree(ecdh25519.X25519)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("x25519 agrees:", ok)
+
+	// In tests, the mock can be swapped in.
+	mock := &ecdhtest.Mock{}
+	ok, err = agree(mock)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("mock agrees:", ok)
+	fmt.Println(mock.Calls())
+
+	// Output:
+	// x25519 agrees: true
+	// mock agrees: true
+	// [GenerateKeyPair GenerateKeyPair SharedSecret SharedSecret]
+}
@@ -0,0 +1,83 @@
+package ecdhtest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+	"golang.org/x/crypto/chacha20"
+)
+
+// Hex encodings of the key pairs and shared secret from RFC 7748, section 6.1.
+const (
+	AlicePrivateKeyHex = "77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a"
+	AlicePublicKeyHex  = "8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a"
+	BobPrivateKeyHex   = "5dab087e624a8a4b79e17f8b83800ee66f3bb1292618b6fd1c2f8b27ff88e0eb"
+	BobPublicKeyHex    = "de9edb7d7b7dc1b4d35b61c2ece435373f8343c85b78674dadfc7e146f882b4f"
+	SharedSecretHex    = "4a5d9d5ba4ce2de1728e3bf480350f25e07e21c947d19e3376f09b3c1e161742"
+)
+
+// Alice returns Alice's key pair from RFC 7748, section 6.1. Every call returns
+// a fresh copy, so tests may modify it.
+func Alice() *ecdh25519.KeyPair {
+	return &ecdh25519.KeyPair{
+		PublicKey:  mustDecodeHex(AlicePublicKeyHex),
+		PrivateKey: mustDecodeHex(AlicePrivateKeyHex),
+	}
+}
+
+// Bob returns Bob's key pair from RFC 7748, section 6.1. Every call returns a
+// fresh copy, so tests may modify it.
+func Bob() *ecdh25519.KeyPair {
+	return &ecdh25519.KeyPair{
+		PublicKey:  mustDecodeHex(BobPublicKeyHex),
+		PrivateKey: mustDecodeHex(BobPrivateKeyHex),
+	}
+}
+
+// SharedSecret returns the shared secret between Alice and Bob from RFC 7748,
+// section 6.1.
+func SharedSecret() ecdh25519.SharedSecret {
+	return mustDecodeHex(SharedSecretHex)
+}
+
+// DeterministicReader returns an endless reader of bytes derived from seed,
+// for generating reproducible keys with ecdh25519.GenerateKeyPair. The same
+// seed always yields the same bytes.
+//
+// Keys generated this way are only as secret as the seed and must never be
+// used outside of tests.
+func DeterministicReader(seed []byte) io.Reader {
+	key := sha256.Sum256(seed)
+	nonce := make([]byte, chacha20.NonceSize)
+
+	c, err := chacha20.NewUnauthenticatedCipher(key[:], nonce)
+	if err != nil {
+		panic(err)
+	}
+
+	return &deterministicReader{c: c}
+}
+
+type deterministicReader struct {
+	c *chacha20.Cipher
+}
+
+func (r *deterministicReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+
+	r.c.XORKeyStream(p, p)
+	return len(p), nil
+}
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
@@ -0,0 +1,63 @@
+package ecdhtest_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+	"github.com/adnsio/ecdh/ecdh25519/ecdhtest"
+)
+
+func TestAliceBob(t *testing.T) {
+	alice, bob := ecdhtest.Alice(), ecdhtest.Bob()
+
+	for _, pair := range []*ecdh25519.KeyPair{alice, bob} {
+		ok, err := pair.PrivateKey.MatchesPublicKey(pair.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !ok {
+			t.Errorf("key pair %x does not match its private key", pair.PublicKey)
+		}
+	}
+
+	got, err := ecdh25519.GenerateSharedSecret(alice.PrivateKey, bob.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := ecdhtest.SharedSecret(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GenerateSharedSecret() = %x, want %x", []byte(got), []byte(want))
+	}
+
+	alice.PrivateKey[0] ^= 1
+	if again := ecdhtest.Alice(); reflect.DeepEqual(again.PrivateKey, alice.PrivateKey) {
+		t.Errorf("Alice() returned a shared key pair")
+	}
+}
+
+func TestDeterministicReader(t *testing.T) {
+	publicKey, privateKey, err := ecdh25519.GenerateKeyPair(ecdhtest.DeterministicReader([]byte("seed")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	againPublicKey, againPrivateKey, err := ecdh25519.GenerateKeyPair(ecdhtest.DeterministicReader([]byte("seed")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(publicKey, againPublicKey) || !reflect.DeepEqual(privateKey, againPrivateKey) {
+		t.Errorf("GenerateKeyPair() with the same seed = %x, then %x", publicKey, againPublicKey)
+	}
+
+	otherPublicKey, _, err := ecdh25519.GenerateKeyPair(ecdhtest.DeterministicReader([]byte("other seed")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reflect.DeepEqual(publicKey, otherPublicKey) {
+		t.Errorf("GenerateKeyPair() with different seeds = %x twice", publicKey)
+	}
+}
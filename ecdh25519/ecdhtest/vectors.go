@@ -0,0 +1,61 @@
+package ecdhtest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+	"golang.org/x/crypto/hkdf"
+)
+
+// Vector is a key agreement test vector between two parties, A and B. All
+// fields are hex encoded so vectors can be shared as JSON with other
+// implementations.
+type Vector struct {
+	PrivateKeyA  string `json:"private_key_a"`
+	PublicKeyA   string `json:"public_key_a"`
+	PrivateKeyB  string `json:"private_key_b"`
+	PublicKeyB   string `json:"public_key_b"`
+	SharedSecret string `json:"shared_secret"`
+}
+
+// GenerateVectors returns n deterministic test vectors derived from seed. The
+// same seed always yields the same vectors, so other X25519 implementations can
+// be checked against this package for compatibility. It returns nil if n is
+// not positive.
+func GenerateVectors(n int, seed []byte) []Vector {
+	if n <= 0 {
+		return nil
+	}
+
+	vectors := make([]Vector, n)
+	for i := range vectors {
+		rand := hkdf.New(sha256.New, seed, nil, []byte("ecdhtest vector "+strconv.Itoa(i)))
+
+		publicKeyA, privateKeyA, err := ecdh25519.GenerateKeyPair(rand)
+		if err != nil {
+			panic(err)
+		}
+
+		publicKeyB, privateKeyB, err := ecdh25519.GenerateKeyPair(rand)
+		if err != nil {
+			panic(err)
+		}
+
+		sharedSecret, err := ecdh25519.GenerateSharedSecret(privateKeyA, publicKeyB)
+		if err != nil {
+			panic(err)
+		}
+
+		vectors[i] = Vector{
+			PrivateKeyA:  hex.EncodeToString(privateKeyA),
+			PublicKeyA:   hex.EncodeToString(publicKeyA),
+			PrivateKeyB:  hex.EncodeToString(privateKeyB),
+			PublicKeyB:   hex.EncodeToString(publicKeyB),
+			SharedSecret: hex.EncodeToString(sharedSecret),
+		}
+	}
+
+	return vectors
+}
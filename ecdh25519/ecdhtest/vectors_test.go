@@ -0,0 +1,83 @@
+package ecdhtest_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+	"github.com/adnsio/ecdh/ecdh25519/ecdhtest"
+)
+
+func TestGenerateVectors(t *testing.T) {
+	seed := []byte("ecdhtest seed")
+
+	vectors := ecdhtest.GenerateVectors(8, seed)
+	if len(vectors) != 8 {
+		t.Fatalf("GenerateVectors() returned %d vectors, want 8", len(vectors))
+	}
+
+	if again := ecdhtest.GenerateVectors(8, seed); !reflect.DeepEqual(vectors, again) {
+		t.Errorf("GenerateVectors() is not deterministic")
+	}
+
+	for _, n := range []int{0, -1} {
+		if got := ecdhtest.GenerateVectors(n, seed); got != nil {
+			t.Errorf("GenerateVectors(%d) = %v, want nil", n, got)
+		}
+	}
+
+	decode := func(s string) []byte {
+		t.Helper()
+
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return b
+	}
+
+	for i, v := range vectors {
+		privateKeyA := ecdh25519.PrivateKey(decode(v.PrivateKeyA))
+		privateKeyB := ecdh25519.PrivateKey(decode(v.PrivateKeyB))
+
+		publicKeyA, err := privateKeyA.PublicKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		publicKeyB, err := privateKeyB.PublicKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if hex.EncodeToString(publicKeyA) != v.PublicKeyA || hex.EncodeToString(publicKeyB) != v.PublicKeyB {
+			t.Errorf("vector %d: public keys do not match private keys", i)
+		}
+
+		sharedSecret, err := ecdh25519.GenerateSharedSecret(privateKeyB, publicKeyA)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if hex.EncodeToString(sharedSecret) != v.SharedSecret {
+			t.Errorf("vector %d: shared secret = %x, want %v", i, sharedSecret, v.SharedSecret)
+		}
+	}
+
+	data, err := json.Marshal(vectors)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded []ecdhtest.Vector
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(vectors, decoded) {
+		t.Errorf("GenerateVectors() does not round trip through JSON")
+	}
+}
@@ -0,0 +1,58 @@
+package ecdh25519
+
+import (
+	"crypto/ed25519"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+
+	"filippo.io/edwards25519"
+)
+
+var (
+	ErrBadEd25519KeyLength       = errors.New("ecdh25519: bad ed25519 key length")
+	ErrMalformedEd25519PublicKey = errors.New("ecdh25519: malformed ed25519 public key")
+)
+
+func init() {
+	registerCapability("ed25519-conversion")
+}
+
+// FromEd25519PrivateKey converts an Ed25519 private key to the X25519 private
+// key with the same underlying scalar. Following RFC 8032, section 5.1.5, the
+// scalar is the first half of the SHA-512 hash of the seed, clamped as
+// described in RFC 7748, section 5.
+//
+// Reusing a key for both signing and key agreement is a common pattern, but
+// callers should make sure their protocols are designed for it.
+func FromEd25519PrivateKey(ed ed25519.PrivateKey) (PrivateKey, error) {
+	if l := len(ed); l != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadEd25519KeyLength, l)
+	}
+
+	h := sha512.Sum512(ed.Seed())
+	defer zeroize(h[:])
+
+	privateKey := make(PrivateKey, PrivateKeySize)
+	copy(privateKey, h[:PrivateKeySize])
+	clamp(privateKey)
+
+	return privateKey, nil
+}
+
+// FromEd25519PublicKey converts an Ed25519 public key to the corresponding
+// X25519 public key, using the birational map from the Edwards y coordinate to
+// the Montgomery u coordinate, u = (1 + y) / (1 - y), from RFC 7748, section 4.1.
+// It returns ErrMalformedEd25519PublicKey if ed is not a valid point encoding.
+func FromEd25519PublicKey(ed ed25519.PublicKey) (PublicKey, error) {
+	if l := len(ed); l != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadEd25519KeyLength, l)
+	}
+
+	p, err := new(edwards25519.Point).SetBytes(ed)
+	if err != nil {
+		return nil, ErrMalformedEd25519PublicKey
+	}
+
+	return p.BytesMontgomery(), nil
+}
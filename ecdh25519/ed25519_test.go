@@ -0,0 +1,130 @@
+package ecdh25519_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+// Ed25519 key from RFC 8032, section 7.1, test 1. The X25519 keys were
+// computed independently with u = (1 + y) / (1 - y) mod p.
+const (
+	ed25519SeedHex            = "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60"
+	ed25519PublicKeyHex       = "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a"
+	x25519FromEdPrivateKeyHex = "307c83864f2833cb427a2ef1c00a013cfdff2768d980c0a3a520f006904de94f"
+	x25519FromEdPublicKeyHex  = "d85e07ec22b0ad881537c2f44d662d1a143cf830c57aca4305d85c7a90f6b62e"
+)
+
+func TestFromEd25519PrivateKey(t *testing.T) {
+	seed, _ := hex.DecodeString(ed25519SeedHex)
+	want, _ := hex.DecodeString(x25519FromEdPrivateKeyHex)
+
+	tests := []struct {
+		name    string
+		ed      ed25519.PrivateKey
+		want    ecdh25519.PrivateKey
+		wantErr error
+	}{
+		{
+			name: "rfc 8032 test 1",
+			ed:   ed25519.NewKeyFromSeed(seed),
+			want: want,
+		},
+		{
+			name:    "with seed only",
+			ed:      seed,
+			wantErr: ecdh25519.ErrBadEd25519KeyLength,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ecdh25519.FromEd25519PrivateKey(tt.ed)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FromEd25519PrivateKey() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FromEd25519PrivateKey() = %x, want %x", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromEd25519PublicKey(t *testing.T) {
+	ed, _ := hex.DecodeString(ed25519PublicKeyHex)
+	want, _ := hex.DecodeString(x25519FromEdPublicKeyHex)
+
+	// y = 2 has no matching x coordinate, so it is not a point on the curve.
+	notOnCurve := make([]byte, ed25519.PublicKeySize)
+	notOnCurve[0] = 2
+
+	tests := []struct {
+		name    string
+		ed      ed25519.PublicKey
+		want    ecdh25519.PublicKey
+		wantErr error
+	}{
+		{
+			name: "rfc 8032 test 1",
+			ed:   ed,
+			want: want,
+		},
+		{
+			name:    "with short key",
+			ed:      ed[:16],
+			wantErr: ecdh25519.ErrBadEd25519KeyLength,
+		},
+		{
+			name:    "with point not on curve",
+			ed:      notOnCurve,
+			wantErr: ecdh25519.ErrMalformedEd25519PublicKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ecdh25519.FromEd25519PublicKey(tt.ed)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FromEd25519PublicKey() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FromEd25519PublicKey() = %x, want %x", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromEd25519_agreement(t *testing.T) {
+	edPublicKey, edPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privateKey, err := ecdh25519.FromEd25519PrivateKey(edPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicKey, err := ecdh25519.FromEd25519PublicKey(edPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	derived, err := privateKey.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !derived.Equal(publicKey) {
+		t.Errorf("FromEd25519PublicKey() = %x, want %x", publicKey, derived)
+	}
+}
@@ -0,0 +1,132 @@
+package ecdh25519
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+// EncryptedPrivateKeyPEMType is the PEM block type of passphrase encrypted
+// ecdh25519 private keys.
+const EncryptedPrivateKeyPEMType = "ENCRYPTED X25519 PRIVATE KEY"
+
+const (
+	encryptedKeyVersion = 1
+	encryptedSaltSize   = 16
+	// encryptedHeaderSize is the size of the authenticated header: version,
+	// scrypt parameters, salt and nonce.
+	encryptedHeaderSize = 4 + encryptedSaltSize + chacha20poly1305.NonceSizeX
+	encryptedKeySize    = encryptedHeaderSize + PrivateKeySize + chacha20poly1305.Overhead
+
+	// scrypt cost parameters for new keys, as recommended for interactive
+	// logins in the scrypt documentation, and the most expensive cost accepted
+	// when parsing. Version 1 files must use exactly scryptR and scryptP, so
+	// with logN capped a crafted file can demand at most 128*r*N = 1 GiB of
+	// memory before the passphrase is checked.
+	scryptLogN    = 15
+	scryptR       = 8
+	scryptP       = 1
+	maxScryptLogN = 20
+)
+
+var (
+	ErrEmptyPassphrase       = errors.New("ecdh25519: empty passphrase")
+	ErrMalformedEncryptedKey = errors.New("ecdh25519: malformed encrypted key")
+	ErrWrongPassphrase       = errors.New("ecdh25519: wrong passphrase")
+)
+
+func init() {
+	registerCapability("scrypt")
+}
+
+// MarshalEncrypted encrypts the private key with a key derived from passphrase
+// and encodes it as an EncryptedPrivateKeyPEMType PEM block, for storing keys
+// at rest.
+//
+// The key is derived with scrypt using a random salt, and the private key is
+// sealed with XChaCha20-Poly1305 under a random nonce. The block records a
+// format version, the scrypt parameters, the salt and the nonce, all of which
+// are authenticated, so ParseEncrypted needs nothing but the passphrase.
+func MarshalEncrypted(privateKey PrivateKey, passphrase []byte) ([]byte, error) {
+	if l := len(privateKey); l != PrivateKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	if len(passphrase) == 0 {
+		return nil, ErrEmptyPassphrase
+	}
+
+	b := make([]byte, encryptedHeaderSize, encryptedKeySize)
+	b[0] = encryptedKeyVersion
+	b[1] = scryptLogN
+	b[2] = scryptR
+	b[3] = scryptP
+	if _, err := io.ReadFull(cryptorand.Reader, b[4:]); err != nil {
+		return nil, err
+	}
+
+	salt := b[4 : 4+encryptedSaltSize]
+	nonce := b[4+encryptedSaltSize : encryptedHeaderSize]
+
+	key, err := scrypt.Key(passphrase, salt, 1<<scryptLogN, scryptR, scryptP, chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, err
+	}
+
+	defer zeroize(key)
+
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+
+	b = aead.Seal(b, nonce, privateKey, b)
+	return pem.EncodeToMemory(&pem.Block{Type: EncryptedPrivateKeyPEMType, Bytes: b}), nil
+}
+
+// ParseEncrypted decrypts a private key encoded by MarshalEncrypted. It returns
+// ErrWrongPassphrase if the passphrase is wrong or the data was modified, and
+// ErrMalformedEncryptedKey if the block cannot have been produced by
+// MarshalEncrypted.
+func ParseEncrypted(data, passphrase []byte) (PrivateKey, error) {
+	b, err := decodePEM(data, EncryptedPrivateKeyPEMType)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) != encryptedKeySize || b[0] != encryptedKeyVersion {
+		return nil, ErrMalformedEncryptedKey
+	}
+
+	logN, r, p := b[1], int(b[2]), int(b[3])
+	if logN < 1 || logN > maxScryptLogN || r != scryptR || p != scryptP {
+		return nil, ErrMalformedEncryptedKey
+	}
+
+	salt := b[4 : 4+encryptedSaltSize]
+	nonce := b[4+encryptedSaltSize : encryptedHeaderSize]
+
+	key, err := scrypt.Key(passphrase, salt, 1<<logN, r, p, chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, ErrMalformedEncryptedKey
+	}
+
+	defer zeroize(key)
+
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := aead.Open(nil, nonce, b[encryptedHeaderSize:], b[:encryptedHeaderSize])
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+
+	return privateKey, nil
+}
@@ -0,0 +1,124 @@
+package ecdh25519_test
+
+import (
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestMarshalEncrypted(t *testing.T) {
+	_, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	passphrase := []byte("correct horse battery staple")
+
+	data, err := ecdh25519.MarshalEncrypted(privateKey, passphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ecdh25519.ParseEncrypted(data, passphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, privateKey) {
+		t.Errorf("ParseEncrypted() = %v, want %v", got, privateKey)
+	}
+
+	block, _ := pem.Decode(data)
+	reencode := func(modify func(b []byte)) []byte {
+		b := append([]byte(nil), block.Bytes...)
+		modify(b)
+		return pem.EncodeToMemory(&pem.Block{Type: block.Type, Bytes: b})
+	}
+
+	tests := []struct {
+		name       string
+		data       []byte
+		passphrase []byte
+		wantErr    error
+	}{
+		{
+			name:       "with wrong passphrase",
+			data:       data,
+			passphrase: []byte("wrong"),
+			wantErr:    ecdh25519.ErrWrongPassphrase,
+		},
+		{
+			name:       "with tampered salt",
+			data:       reencode(func(b []byte) { b[4] ^= 1 }),
+			passphrase: passphrase,
+			wantErr:    ecdh25519.ErrWrongPassphrase,
+		},
+		{
+			name:       "with unknown version",
+			data:       reencode(func(b []byte) { b[0] = 2 }),
+			passphrase: passphrase,
+			wantErr:    ecdh25519.ErrMalformedEncryptedKey,
+		},
+		{
+			name:       "with excessive cost",
+			data:       reencode(func(b []byte) { b[1] = 40 }),
+			passphrase: passphrase,
+			wantErr:    ecdh25519.ErrMalformedEncryptedKey,
+		},
+		{
+			name:       "with excessive block size",
+			data:       reencode(func(b []byte) { b[1], b[2] = 20, 255 }),
+			passphrase: passphrase,
+			wantErr:    ecdh25519.ErrMalformedEncryptedKey,
+		},
+		{
+			name:       "with excessive parallelism",
+			data:       reencode(func(b []byte) { b[3] = 255 }),
+			passphrase: passphrase,
+			wantErr:    ecdh25519.ErrMalformedEncryptedKey,
+		},
+		{
+			name:       "with truncated block",
+			data:       pem.EncodeToMemory(&pem.Block{Type: block.Type, Bytes: block.Bytes[:20]}),
+			passphrase: passphrase,
+			wantErr:    ecdh25519.ErrMalformedEncryptedKey,
+		},
+		{
+			name:       "with unencrypted key",
+			data:       mustMarshalPEM(t, privateKey),
+			passphrase: passphrase,
+			wantErr:    ecdh25519.ErrMalformedPEM,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ecdh25519.ParseEncrypted(tt.data, tt.passphrase); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseEncrypted() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := ecdh25519.MarshalEncrypted(privateKey, nil); !errors.Is(err, ecdh25519.ErrEmptyPassphrase) {
+		t.Errorf("MarshalEncrypted() error = %v, want %v", err, ecdh25519.ErrEmptyPassphrase)
+	}
+
+	if _, err := ecdh25519.MarshalEncrypted(privateKey[:16], passphrase); !errors.Is(err, ecdh25519.ErrBadPrivateKeyLength) {
+		t.Errorf("MarshalEncrypted() error = %v, want %v", err, ecdh25519.ErrBadPrivateKeyLength)
+	}
+}
+
+func mustMarshalPEM(t *testing.T, privateKey ecdh25519.PrivateKey) []byte {
+	t.Helper()
+
+	data, err := privateKey.MarshalPEM()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
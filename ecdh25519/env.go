@@ -0,0 +1,62 @@
+package ecdh25519
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var (
+	ErrEnvNotSet          = errors.New("ecdh25519: environment variable not set")
+	ErrMalformedBase64Key = errors.New("ecdh25519: malformed base64 key")
+)
+
+// PrivateKeyFromEnv reads a standard base64 encoded private key from the
+// environment variable name. Surrounding whitespace is ignored. It returns
+// ErrEnvNotSet if the variable is unset or empty, ErrMalformedBase64Key if it
+// does not decode, and ErrBadPrivateKeyLength if the decoded key has the wrong
+// length. The errors name the variable but never include its value.
+func PrivateKeyFromEnv(name string) (PrivateKey, error) {
+	b, err := keyFromEnv(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if l := len(b); l != PrivateKeySize {
+		zeroize(b)
+		return nil, fmt.Errorf("%w: %d: %s", ErrBadPrivateKeyLength, l, name)
+	}
+
+	return b, nil
+}
+
+// PublicKeyFromEnv is like PrivateKeyFromEnv, but it reads a public key and
+// returns ErrBadPublicKeyLength if the decoded key has the wrong length.
+func PublicKeyFromEnv(name string) (PublicKey, error) {
+	b, err := keyFromEnv(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if l := len(b); l != PublicKeySize {
+		return nil, fmt.Errorf("%w: %d: %s", ErrBadPublicKeyLength, l, name)
+	}
+
+	return b, nil
+}
+
+func keyFromEnv(name string) ([]byte, error) {
+	value := strings.TrimSpace(os.Getenv(name))
+	if value == "" {
+		return nil, fmt.Errorf("%w: %s", ErrEnvNotSet, name)
+	}
+
+	b, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedBase64Key, name)
+	}
+
+	return b, nil
+}
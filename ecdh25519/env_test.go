@@ -0,0 +1,90 @@
+package ecdh25519_test
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestPrivateKeyFromEnv(t *testing.T) {
+	publicKey, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("ECDH25519_PUBLIC_KEY", base64.StdEncoding.EncodeToString(publicKey))
+	t.Setenv("ECDH25519_PRIVATE_KEY", " "+base64.StdEncoding.EncodeToString(privateKey)+"\n")
+
+	gotPublicKey, err := ecdh25519.PublicKeyFromEnv("ECDH25519_PUBLIC_KEY")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gotPrivateKey, err := ecdh25519.PrivateKeyFromEnv("ECDH25519_PRIVATE_KEY")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(gotPublicKey, publicKey) || !reflect.DeepEqual(gotPrivateKey, privateKey) {
+		t.Errorf("KeyFromEnv() = %v, %v, want %v, %v", gotPublicKey, gotPrivateKey, publicKey, privateKey)
+	}
+}
+
+func TestPrivateKeyFromEnv_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		set     bool
+		wantErr error
+	}{
+		{
+			name:    "unset",
+			wantErr: ecdh25519.ErrEnvNotSet,
+		},
+		{
+			name:    "empty",
+			set:     true,
+			wantErr: ecdh25519.ErrEnvNotSet,
+		},
+		{
+			name:    "with bad base64",
+			value:   "not base64!",
+			set:     true,
+			wantErr: ecdh25519.ErrMalformedBase64Key,
+		},
+		{
+			name:    "with short key",
+			value:   base64.StdEncoding.EncodeToString(make([]byte, 16)),
+			set:     true,
+			wantErr: ecdh25519.ErrBadPrivateKeyLength,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const name = "ECDH25519_TEST_KEY"
+			if tt.set {
+				t.Setenv(name, tt.value)
+			}
+
+			_, err := ecdh25519.PrivateKeyFromEnv(name)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PrivateKeyFromEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err == nil || !strings.Contains(err.Error(), name) {
+				t.Errorf("PrivateKeyFromEnv() error = %v, want it to name %s", err, name)
+			}
+		})
+	}
+
+	t.Setenv("ECDH25519_TEST_KEY", base64.StdEncoding.EncodeToString(make([]byte, 16)))
+	if _, err := ecdh25519.PublicKeyFromEnv("ECDH25519_TEST_KEY"); !errors.Is(err, ecdh25519.ErrBadPublicKeyLength) {
+		t.Errorf("PublicKeyFromEnv() error = %v, want %v", err, ecdh25519.ErrBadPublicKeyLength)
+	}
+}
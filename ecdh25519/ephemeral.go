@@ -0,0 +1,29 @@
+package ecdh25519
+
+import "io"
+
+// EphemeralSharedSecret runs the sender side of an ephemeral-static key
+// agreement, as used by ECIES-style encryption. It generates an ephemeral key
+// pair using entropy from rand, computes the shared secret with peer and
+// zeroizes the ephemeral private key before returning, so it never reaches the
+// caller. The ephemeral public key must be sent to the peer, which computes the
+// same secret with GenerateSharedSecret. If rand is nil, crypto/rand.Reader
+// will be used.
+//
+// The raw secret should be passed through a key derivation function, such as
+// DeriveKey, before use.
+func EphemeralSharedSecret(rand io.Reader, peer PublicKey) (ephemeralPublicKey PublicKey, sharedSecret SharedSecret, err error) {
+	ephemeralPublicKey, ephemeralPrivateKey, err := GenerateKeyPair(rand)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer ephemeralPrivateKey.Zeroize()
+
+	sharedSecret, err = GenerateSharedSecret(ephemeralPrivateKey, peer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ephemeralPublicKey, sharedSecret, nil
+}
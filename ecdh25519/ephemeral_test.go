@@ -0,0 +1,35 @@
+package ecdh25519_test
+
+import (
+	"crypto/rand"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestEphemeralSharedSecret(t *testing.T) {
+	staticPublicKey, staticPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ephemeralPublicKey, got, err := ecdh25519.EphemeralSharedSecret(rand.Reader, staticPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := ecdh25519.GenerateSharedSecret(staticPrivateKey, ephemeralPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EphemeralSharedSecret() = %v, want %v", got, want)
+	}
+
+	if _, _, err := ecdh25519.EphemeralSharedSecret(rand.Reader, make([]byte, ecdh25519.PublicKeySize)); !errors.Is(err, ecdh25519.ErrLowOrderPoint) {
+		t.Errorf("EphemeralSharedSecret() error = %v, want %v", err, ecdh25519.ErrLowOrderPoint)
+	}
+}
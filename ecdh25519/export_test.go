@@ -0,0 +1,8 @@
+package ecdh25519
+
+// SetGenerateSharedSecretsBounded replaces the batch worker of
+// GenerateSharedSecrets with f and returns a function that restores it.
+func SetGenerateSharedSecretsBounded(f func(PrivateKey, []PublicKey, int) ([]SharedSecret, []error)) (restore func()) {
+	generateSharedSecretsBounded = f
+	return func() { generateSharedSecretsBounded = GenerateSharedSecretsBounded }
+}
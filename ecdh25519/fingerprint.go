@@ -0,0 +1,87 @@
+package ecdh25519
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrMalformedFingerprint = errors.New("ecdh25519: malformed fingerprint")
+
+// fingerprintPrefix is the prefix of base64 fingerprints, as used by SSH.
+const fingerprintPrefix = "SHA256:"
+
+// VerifyFingerprint reports whether expected is the fingerprint of publicKey,
+// the SHA-256 hash of the key bytes. The fingerprint may be given as
+// colon-separated hex ("ab:cd:...") or as unpadded base64 with an optional
+// "SHA256:" prefix, as printed by SSH. The comparison runs in constant time.
+func VerifyFingerprint(publicKey PublicKey, expected string) (bool, error) {
+	if l := len(publicKey); l != PublicKeySize {
+		return false, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	want, err := parseFingerprint(expected)
+	if err != nil {
+		return false, err
+	}
+
+	got := fingerprint(publicKey)
+	return subtle.ConstantTimeCompare(got[:], want) == 1, nil
+}
+
+// Fingerprint returns the fingerprint of the public key, the SHA-256 hash of the
+// key bytes, formatted like the ones printed by ssh-keygen: "SHA256:" followed
+// by unpadded base64. VerifyFingerprint accepts it. It returns the empty string
+// if p is not PublicKeySize bytes long.
+func (p PublicKey) Fingerprint() string {
+	if len(p) != PublicKeySize {
+		return ""
+	}
+
+	f := fingerprint(p)
+	return fingerprintPrefix + base64.RawStdEncoding.EncodeToString(f[:])
+}
+
+// FingerprintBytes returns the raw SHA-256 fingerprint of the public key, for
+// programmatic comparison. It returns nil if p is not PublicKeySize bytes long.
+func (p PublicKey) FingerprintBytes() []byte {
+	if len(p) != PublicKeySize {
+		return nil
+	}
+
+	f := fingerprint(p)
+	return f[:]
+}
+
+func fingerprint(publicKey PublicKey) [sha256.Size]byte {
+	return sha256.Sum256(publicKey)
+}
+
+func parseFingerprint(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+
+	var (
+		b   []byte
+		err error
+	)
+
+	if strings.Contains(s, ":") && !strings.HasPrefix(s, fingerprintPrefix) {
+		b, err = hex.DecodeString(strings.ReplaceAll(s, ":", ""))
+	} else {
+		b, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(strings.TrimPrefix(s, fingerprintPrefix), "="))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedFingerprint, err)
+	}
+
+	if len(b) != sha256.Size {
+		return nil, fmt.Errorf("%w: bad length: %d", ErrMalformedFingerprint, len(b))
+	}
+
+	return b, nil
+}
@@ -0,0 +1,108 @@
+package ecdh25519_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestVerifyFingerprint(t *testing.T) {
+	alicePublicKey, err := hex.DecodeString("8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		expected string
+		want     bool
+		wantErr  error
+	}{
+		{
+			name:     "with matching colon hex",
+			expected: "30:0c:9c:96:03:b9:2a:4b:39:ed:39:58:bf:92:40:11:48:04:db:4f:d3:73:01:2c:0c:a4:74:32:d6:34:25:ae",
+			want:     true,
+		},
+		{
+			name:     "with matching upper case colon hex",
+			expected: "30:0C:9C:96:03:B9:2A:4B:39:ED:39:58:BF:92:40:11:48:04:DB:4F:D3:73:01:2C:0C:A4:74:32:D6:34:25:AE",
+			want:     true,
+		},
+		{
+			name:     "with matching base64",
+			expected: "SHA256:MAyclgO5Kks57TlYv5JAEUgE20/TcwEsDKR0MtY0Ja4",
+			want:     true,
+		},
+		{
+			name:     "with matching base64 without prefix",
+			expected: "MAyclgO5Kks57TlYv5JAEUgE20/TcwEsDKR0MtY0Ja4",
+			want:     true,
+		},
+		{
+			name:     "with non-matching colon hex",
+			expected: "31:0c:9c:96:03:b9:2a:4b:39:ed:39:58:bf:92:40:11:48:04:db:4f:d3:73:01:2c:0c:a4:74:32:d6:34:25:ae",
+		},
+		{
+			name:     "with non-matching base64",
+			expected: "SHA256:NAyclgO5Kks57TlYv5JAEUgE20/TcwEsDKR0MtY0Ja4",
+		},
+		{
+			name:     "with malformed hex",
+			expected: "zz:0c",
+			wantErr:  ecdh25519.ErrMalformedFingerprint,
+		},
+		{
+			name:     "with short base64",
+			expected: "SHA256:MAycl",
+			wantErr:  ecdh25519.ErrMalformedFingerprint,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ecdh25519.VerifyFingerprint(alicePublicKey, tt.expected)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyFingerprint() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("VerifyFingerprint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublicKey_Fingerprint(t *testing.T) {
+	alicePublicKey, err := hex.DecodeString("8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicKey := ecdh25519.PublicKey(alicePublicKey)
+
+	if got, want := publicKey.Fingerprint(), "SHA256:MAyclgO5Kks57TlYv5JAEUgE20/TcwEsDKR0MtY0Ja4"; got != want {
+		t.Errorf("PublicKey.Fingerprint() = %v, want %v", got, want)
+	}
+
+	if got, want := hex.EncodeToString(publicKey.FingerprintBytes()), "300c9c9603b92a4b39ed3958bf9240114804db4fd373012c0ca47432d63425ae"; got != want {
+		t.Errorf("PublicKey.FingerprintBytes() = %v, want %v", got, want)
+	}
+
+	ok, err := ecdh25519.VerifyFingerprint(publicKey, publicKey.Fingerprint())
+	if err != nil || !ok {
+		t.Errorf("VerifyFingerprint(PublicKey.Fingerprint()) = %v, %v, want true, nil", ok, err)
+	}
+
+	for _, malformed := range []ecdh25519.PublicKey{nil, publicKey[:16]} {
+		if got := malformed.Fingerprint(); got != "" {
+			t.Errorf("PublicKey.Fingerprint() with %d-byte key = %v, want empty", len(malformed), got)
+		}
+
+		if got := malformed.FingerprintBytes(); got != nil {
+			t.Errorf("PublicKey.FingerprintBytes() with %d-byte key = %x, want nil", len(malformed), got)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package ecdh25519_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+	"github.com/adnsio/ecdh/ecdh25519/ecdhtest"
+)
+
+// fuzzSeeds returns the RFC 7748 keys and the known low-order points, to seed
+// the fuzzers with interesting public keys.
+func fuzzSeeds(f *testing.F) [][]byte {
+	f.Helper()
+
+	seeds := [][]byte{nil, ecdhtest.Alice().PublicKey, ecdhtest.Bob().PublicKey}
+	for _, point := range lowOrderPoints {
+		b, err := hex.DecodeString(point)
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		seeds = append(seeds, b)
+	}
+
+	return seeds
+}
+
+func FuzzGenerateSharedSecret(f *testing.F) {
+	for _, seed := range fuzzSeeds(f) {
+		f.Add([]byte(ecdhtest.Alice().PrivateKey), seed)
+	}
+
+	f.Fuzz(func(t *testing.T, privateKey, publicKey []byte) {
+		sharedSecret, err := ecdh25519.GenerateSharedSecret(privateKey, publicKey)
+		if err != nil {
+			if !errors.Is(err, ecdh25519.ErrBadPrivateKeyLength) &&
+				!errors.Is(err, ecdh25519.ErrBadPublicKeyLength) &&
+				!errors.Is(err, ecdh25519.ErrLowOrderPoint) {
+				t.Fatalf("GenerateSharedSecret() error = %v, want a defined error", err)
+			}
+
+			return
+		}
+
+		if l := len(sharedSecret); l != ecdh25519.SharedSecretSize {
+			t.Fatalf("GenerateSharedSecret() returned %d bytes", l)
+		}
+	})
+}
+
+func FuzzUnmarshalPublicKey(f *testing.F) {
+	for _, seed := range fuzzSeeds(f) {
+		f.Add(seed)
+		f.Add([]byte(hex.EncodeToString(seed)))
+
+		if der, err := ecdh25519.MarshalRawPublicKey(seed); err == nil {
+			f.Add(der)
+		}
+
+		if pem, err := ecdh25519.PublicKey(seed).MarshalPEM(); err == nil {
+			f.Add(pem)
+		}
+
+		if line, err := ecdh25519.MarshalOpenSSHPublicKey(seed, "comment"); err == nil {
+			f.Add(line)
+		}
+
+		if data, err := json.Marshal(ecdh25519.PublicKey(seed)); err == nil {
+			f.Add(data)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		check := func(name string, publicKey ecdh25519.PublicKey, err error, defined ...error) {
+			t.Helper()
+
+			if err != nil {
+				for _, want := range defined {
+					if errors.Is(err, want) {
+						return
+					}
+				}
+
+				if defined != nil {
+					t.Fatalf("%s error = %v, want one of %v", name, err, defined)
+				}
+
+				return
+			}
+
+			if l := len(publicKey); l != ecdh25519.PublicKeySize {
+				t.Fatalf("%s returned a %d-byte key", name, l)
+			}
+		}
+
+		var publicKey ecdh25519.PublicKey
+		err := publicKey.UnmarshalBinary(data)
+		check("PublicKey.UnmarshalBinary()", publicKey, err, ecdh25519.ErrBadPublicKeyLength)
+
+		publicKey = nil
+		err = publicKey.UnmarshalText(data)
+		check("PublicKey.UnmarshalText()", publicKey, err, ecdh25519.ErrBadPublicKeyLength, ecdh25519.ErrMalformedHexKey)
+
+		// Syntax errors come from encoding/json, so only the result is checked.
+		publicKey = nil
+		err = json.Unmarshal(data, &publicKey)
+		if err == nil && publicKey != nil {
+			check("PublicKey.UnmarshalJSON()", publicKey, nil)
+		}
+
+		publicKey, err = ecdh25519.ParseRawPublicKey(data)
+		check("ParseRawPublicKey()", publicKey, err, ecdh25519.ErrMalformedRawPublicKey, ecdh25519.ErrBadPublicKeyLength)
+
+		publicKey, err = ecdh25519.ParsePublicKeyPEM(data)
+		check("ParsePublicKeyPEM()", publicKey, err, ecdh25519.ErrMalformedPEM, ecdh25519.ErrBadPublicKeyLength)
+
+		publicKey, _, err = ecdh25519.ParseOpenSSHPublicKey(data)
+		check("ParseOpenSSHPublicKey()", publicKey, err, ecdh25519.ErrMalformedOpenSSHPublicKey, ecdh25519.ErrBadPublicKeyLength)
+	})
+}
@@ -0,0 +1,189 @@
+package ecdh25519
+
+import (
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"io"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+var (
+	ErrMalformedHandshake      = errors.New("ecdh25519: malformed handshake message")
+	ErrHandshakeAuthentication = errors.New("ecdh25519: handshake message authentication failed")
+)
+
+var (
+	sealHandshakeInfo   = []byte("ecdh25519 seal handshake")
+	serverHandshakeInfo = []byte("ecdh25519 server handshake")
+	encryptionKeyInfo   = []byte("ecdh25519 handshake encryption key")
+	confirmationKeyInfo = []byte("ecdh25519 handshake confirmation key")
+)
+
+// HandshakeKeySize is the size, in bytes, of keys derived by ClientHandshake
+// and ServerHandshake.
+const HandshakeKeySize = 32
+
+// SealHandshake encrypts plaintext to a peer's static public key in a single
+// message, for one round trip handshakes. It performs the key agreement between
+// ephemeralPrivateKey and peerStatic, derives a ChaCha20-Poly1305 key from the
+// shared secret and both public keys with HKDF-SHA256, and returns the
+// ephemeral public key followed by the ciphertext. The aad is authenticated but
+// not encrypted.
+//
+// The ephemeral private key must be freshly generated for every message and
+// never reused, as the derived key is used with a fixed nonce. The message is
+// only forward secret with respect to the ephemeral key: anyone who later
+// obtains the peer's static private key can decrypt recorded messages. Nothing
+// ties the message to a fresh contribution from the peer either, so it can be
+// replayed; protocols must handle that at a higher level.
+func SealHandshake(ephemeralPrivateKey PrivateKey, peerStatic PublicKey, plaintext, aad []byte) ([]byte, error) {
+	ephemeralPublicKey, err := ephemeralPrivateKey.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := handshakeAEAD(ephemeralPrivateKey, peerStatic, ephemeralPublicKey, peerStatic)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+	return aead.Seal(ephemeralPublicKey, nonce, plaintext, aad), nil
+}
+
+// OpenHandshake decrypts a message produced by SealHandshake using the
+// recipient's static private key.
+func OpenHandshake(staticPrivateKey PrivateKey, message, aad []byte) ([]byte, error) {
+	if len(message) < PublicKeySize+chacha20poly1305.Overhead {
+		return nil, ErrMalformedHandshake
+	}
+
+	staticPublicKey, err := staticPrivateKey.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	ephemeralPublicKey := PublicKey(message[:PublicKeySize])
+	aead, err := handshakeAEAD(staticPrivateKey, ephemeralPublicKey, ephemeralPublicKey, staticPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+	plaintext, err := aead.Open(nil, nonce, message[PublicKeySize:], aad)
+	if err != nil {
+		return nil, ErrHandshakeAuthentication
+	}
+
+	return plaintext, nil
+}
+
+func handshakeAEAD(privateKey PrivateKey, publicKey, ephemeralPublicKey, staticPublicKey PublicKey) (cipher.AEAD, error) {
+	sharedSecret, err := GenerateSharedSecret(privateKey, publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 0, 2*PublicKeySize)
+	salt = append(salt, ephemeralPublicKey...)
+	salt = append(salt, staticPublicKey...)
+
+	key := make([]byte, chacha20poly1305.KeySize)
+	if err := hkdfExpand(key, sharedSecret, salt, sealHandshakeInfo); err != nil {
+		return nil, err
+	}
+
+	return chacha20poly1305.New(key)
+}
+
+// ClientHandshake runs the client side of a handshake with a server whose static
+// public key is known in advance, like the Noise NK pattern. It generates an
+// ephemeral key pair using entropy from rand, agrees on a secret with
+// serverStatic and derives a key bound to the server's identity and to context.
+// The ephemeral public key must be sent to the server, which derives the same
+// key with ServerHandshake. If rand is nil, crypto/rand.Reader will be used.
+func ClientHandshake(rand io.Reader, serverStatic PublicKey, context []byte) (clientEphemeralPublicKey PublicKey, key []byte, err error) {
+	clientEphemeralPublicKey, clientEphemeralPrivateKey, err := GenerateKeyPair(rand)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer clientEphemeralPrivateKey.Zeroize()
+
+	sharedSecret, err := GenerateSharedSecret(clientEphemeralPrivateKey, serverStatic)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key, err = handshakeKey(sharedSecret, serverStatic, clientEphemeralPublicKey, context)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clientEphemeralPublicKey, key, nil
+}
+
+// ServerHandshake runs the server side of a handshake started with
+// ClientHandshake.
+func ServerHandshake(serverStatic PrivateKey, clientEphemeralPublicKey PublicKey, context []byte) ([]byte, error) {
+	serverStaticPublicKey, err := serverStatic.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	sharedSecret, err := GenerateSharedSecret(serverStatic, clientEphemeralPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return handshakeKey(sharedSecret, serverStaticPublicKey, clientEphemeralPublicKey, context)
+}
+
+// handshakeKey derives a handshake key with HKDF-SHA256, using the server's
+// static public key, the client's ephemeral public key and the context as info.
+func handshakeKey(sharedSecret []byte, serverStatic, clientEphemeralPublicKey PublicKey, context []byte) ([]byte, error) {
+	info := make([]byte, 0, len(serverHandshakeInfo)+2*PublicKeySize+len(context))
+	info = append(info, serverHandshakeInfo...)
+	info = append(info, serverStatic...)
+	info = append(info, clientEphemeralPublicKey...)
+	info = append(info, context...)
+
+	key := make([]byte, HandshakeKeySize)
+	if err := hkdfExpand(key, sharedSecret, nil, info); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// FinalizeHandshake derives an encryption key and a key confirmation tag from a
+// shared secret and the handshake transcript. The encryption key and a MAC key
+// are derived with HKDF-SHA256, or the KDF chosen with opts, under distinct
+// labels, and the tag is the HMAC-SHA256 of the transcript under the MAC key.
+// Both sides run it over the same transcript and compare tags, with hmac.Equal,
+// before using the key.
+func FinalizeHandshake(secret, transcript []byte, opts ...DeriveOption) (encKey [32]byte, confirmTag []byte, err error) {
+	c, err := resolveDeriveOptions(opts)
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+
+	if err := c.prf.Derive(encKey[:], secret, nil, encryptionKeyInfo); err != nil {
+		return [32]byte{}, nil, err
+	}
+
+	macKey := make([]byte, sha256.Size)
+	defer zeroize(macKey)
+
+	if err := c.prf.Derive(macKey, secret, nil, confirmationKeyInfo); err != nil {
+		return [32]byte{}, nil, err
+	}
+
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write(transcript)
+
+	return encKey, mac.Sum(nil), nil
+}
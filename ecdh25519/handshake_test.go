@@ -0,0 +1,198 @@
+package ecdh25519_test
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestSealHandshake(t *testing.T) {
+	staticPublicKey, staticPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, ephemeralPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plaintext := []byte("hello")
+	aad := []byte("header")
+
+	message, err := ecdh25519.SealHandshake(ephemeralPrivateKey, staticPublicKey, plaintext, aad)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ecdh25519.OpenHandshake(staticPrivateKey, message, aad)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, plaintext) {
+		t.Errorf("OpenHandshake() = %v, want %v", got, plaintext)
+	}
+
+	tampered := append([]byte(nil), message...)
+	tampered[len(tampered)-1] ^= 1
+
+	_, otherPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		privateKey ecdh25519.PrivateKey
+		message    []byte
+		aad        []byte
+		wantErr    error
+	}{
+		{
+			name:       "with tampered message",
+			privateKey: staticPrivateKey,
+			message:    tampered,
+			aad:        aad,
+			wantErr:    ecdh25519.ErrHandshakeAuthentication,
+		},
+		{
+			name:       "with wrong aad",
+			privateKey: staticPrivateKey,
+			message:    message,
+			aad:        []byte("other"),
+			wantErr:    ecdh25519.ErrHandshakeAuthentication,
+		},
+		{
+			name:       "with wrong private key",
+			privateKey: otherPrivateKey,
+			message:    message,
+			aad:        aad,
+			wantErr:    ecdh25519.ErrHandshakeAuthentication,
+		},
+		{
+			name:       "with short message",
+			privateKey: staticPrivateKey,
+			message:    message[:ecdh25519.PublicKeySize],
+			aad:        aad,
+			wantErr:    ecdh25519.ErrMalformedHandshake,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ecdh25519.OpenHandshake(tt.privateKey, tt.message, tt.aad); !errors.Is(err, tt.wantErr) {
+				t.Errorf("OpenHandshake() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClientHandshake(t *testing.T) {
+	serverPublicKey, serverPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	context := []byte("example protocol v1")
+
+	clientEphemeralPublicKey, clientKey, err := ecdh25519.ClientHandshake(rand.Reader, serverPublicKey, context)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serverKey, err := ecdh25519.ServerHandshake(serverPrivateKey, clientEphemeralPublicKey, context)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(clientKey, serverKey) {
+		t.Errorf("ClientHandshake() key = %x, ServerHandshake() key = %x", clientKey, serverKey)
+	}
+
+	otherKey, err := ecdh25519.ServerHandshake(serverPrivateKey, clientEphemeralPublicKey, []byte("other protocol"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reflect.DeepEqual(clientKey, otherKey) {
+		t.Errorf("ServerHandshake() derived the same key for a different context")
+	}
+
+	_, impostorPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	impostorKey, err := ecdh25519.ServerHandshake(impostorPrivateKey, clientEphemeralPublicKey, context)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reflect.DeepEqual(clientKey, impostorKey) {
+		t.Errorf("ServerHandshake() derived the client key without the server private key")
+	}
+
+	if _, _, err := ecdh25519.ClientHandshake(rand.Reader, serverPublicKey[:16], context); !errors.Is(err, ecdh25519.ErrBadPublicKeyLength) {
+		t.Errorf("ClientHandshake() error = %v, want %v", err, ecdh25519.ErrBadPublicKeyLength)
+	}
+}
+
+func TestFinalizeHandshake(t *testing.T) {
+	alicePublicKey, alicePrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bobPublicKey, bobPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	aliceSharedSecret, err := ecdh25519.GenerateSharedSecret(alicePrivateKey, bobPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bobSharedSecret, err := ecdh25519.GenerateSharedSecret(bobPrivateKey, alicePublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transcript := append(append([]byte(nil), alicePublicKey...), bobPublicKey...)
+
+	aliceKey, aliceTag, err := ecdh25519.FinalizeHandshake(aliceSharedSecret, transcript)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bobKey, bobTag, err := ecdh25519.FinalizeHandshake(bobSharedSecret, transcript)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if aliceKey != bobKey {
+		t.Errorf("FinalizeHandshake() keys = %x, %x, want equal", aliceKey, bobKey)
+	}
+
+	if !hmac.Equal(aliceTag, bobTag) {
+		t.Errorf("FinalizeHandshake() tags = %x, %x, want equal", aliceTag, bobTag)
+	}
+
+	if reflect.DeepEqual(aliceKey[:], aliceTag) {
+		t.Errorf("FinalizeHandshake() tag equals the encryption key")
+	}
+
+	_, otherTag, err := ecdh25519.FinalizeHandshake(bobSharedSecret, transcript[:ecdh25519.PublicKeySize])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hmac.Equal(aliceTag, otherTag) {
+		t.Errorf("FinalizeHandshake() tag did not change with the transcript")
+	}
+}
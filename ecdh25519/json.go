@@ -0,0 +1,99 @@
+package ecdh25519
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+func init() {
+	registerCapability("json")
+}
+
+// MarshalJSON implements json.Marshaler. The key is encoded as an unpadded
+// base64url string, like the "x" and "d" members of an OKP JSON Web Key
+// (RFC 8037). A nil key is encoded as null.
+func (p PublicKey) MarshalJSON() ([]byte, error) {
+	if p == nil {
+		return []byte("null"), nil
+	}
+
+	if l := len(p); l != PublicKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	return json.Marshal(base64.RawURLEncoding.EncodeToString(p))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A null value leaves a nil key. On
+// error, p is left unchanged.
+func (p *PublicKey) UnmarshalJSON(data []byte) error {
+	b, err := unmarshalJSONKey(data)
+	if err != nil {
+		return err
+	}
+
+	if b == nil {
+		*p = nil
+		return nil
+	}
+
+	if l := len(b); l != PublicKeySize {
+		return fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	*p = b
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The key is encoded as an unpadded
+// base64url string, like the "x" and "d" members of an OKP JSON Web Key
+// (RFC 8037). A nil key is encoded as null.
+func (p PrivateKey) MarshalJSON() ([]byte, error) {
+	if p == nil {
+		return []byte("null"), nil
+	}
+
+	if l := len(p); l != PrivateKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	return json.Marshal(base64.RawURLEncoding.EncodeToString(p))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A null value leaves a nil key. On
+// error, p is left unchanged and any decoded bytes are zeroized.
+func (p *PrivateKey) UnmarshalJSON(data []byte) error {
+	b, err := unmarshalJSONKey(data)
+	if err != nil {
+		zeroize(b)
+		return err
+	}
+
+	if b == nil {
+		*p = nil
+		return nil
+	}
+
+	if l := len(b); l != PrivateKeySize {
+		zeroize(b)
+		return fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	*p = b
+	return nil
+}
+
+// unmarshalJSONKey decodes a base64url JSON string, returning nil for null.
+func unmarshalJSONKey(data []byte) ([]byte, error) {
+	if string(data) == "null" {
+		return nil, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+
+	return base64.RawURLEncoding.DecodeString(s)
+}
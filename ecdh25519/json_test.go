@@ -0,0 +1,147 @@
+package ecdh25519_test
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestPublicKey_MarshalJSON(t *testing.T) {
+	publicKey, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type keys struct {
+		PublicKey  ecdh25519.PublicKey  `json:"x"`
+		PrivateKey ecdh25519.PrivateKey `json:"d"`
+	}
+
+	data, err := json.Marshal(keys{PublicKey: publicKey, PrivateKey: privateKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got keys
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got.PublicKey, publicKey) || !reflect.DeepEqual(got.PrivateKey, privateKey) {
+		t.Errorf("json round trip = %v, want %v", got, keys{PublicKey: publicKey, PrivateKey: privateKey})
+	}
+}
+
+func TestPublicKey_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    ecdh25519.PublicKey
+		wantErr error
+	}{
+		{
+			name: "rfc 8037 x25519 key",
+			data: `"3p7bfXt9wbTTW2HC7OQ1Nz-DQ8hbeGdNrfx-FG-IK08"`,
+			want: []byte{
+				0xde, 0x9e, 0xdb, 0x7d, 0x7b, 0x7d, 0xc1, 0xb4, 0xd3, 0x5b, 0x61, 0xc2, 0xec, 0xe4, 0x35, 0x37,
+				0x3f, 0x83, 0x43, 0xc8, 0x5b, 0x78, 0x67, 0x4d, 0xad, 0xfc, 0x7e, 0x14, 0x6f, 0x88, 0x2b, 0x4f,
+			},
+		},
+		{
+			name: "null",
+			data: `null`,
+		},
+		{
+			name:    "with short key",
+			data:    `"AAAA"`,
+			wantErr: ecdh25519.ErrBadPublicKeyLength,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got ecdh25519.PublicKey
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PublicKey.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PublicKey.UnmarshalJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrivateKey_UnmarshalJSON(t *testing.T) {
+	var privateKey ecdh25519.PrivateKey
+	if err := json.Unmarshal([]byte(`"AAAA"`), &privateKey); !errors.Is(err, ecdh25519.ErrBadPrivateKeyLength) {
+		t.Errorf("PrivateKey.UnmarshalJSON() error = %v, want %v", err, ecdh25519.ErrBadPrivateKeyLength)
+	}
+
+	if err := json.Unmarshal([]byte(`"not base64!"`), &privateKey); err == nil {
+		t.Errorf("PrivateKey.UnmarshalJSON() error = nil, want error")
+	}
+
+	if err := json.Unmarshal([]byte(`null`), &privateKey); err != nil || privateKey != nil {
+		t.Errorf("PrivateKey.UnmarshalJSON(null) = %v, %v, want nil, nil", privateKey, err)
+	}
+}
+
+func TestUnmarshalJSON_errors(t *testing.T) {
+	publicKey, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name              string
+		data              string
+		wantPublicKeyErr  error
+		wantPrivateKeyErr error
+	}{
+		{
+			name: "with non-string value",
+			data: `123`,
+		},
+		{
+			name: "with bad base64",
+			data: `"not base64!"`,
+		},
+		{
+			name:              "with short key",
+			data:              `"AAAA"`,
+			wantPublicKeyErr:  ecdh25519.ErrBadPublicKeyLength,
+			wantPrivateKeyErr: ecdh25519.ErrBadPrivateKeyLength,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPublicKey := append(ecdh25519.PublicKey(nil), publicKey...)
+			err := gotPublicKey.UnmarshalJSON([]byte(tt.data))
+			if err == nil || (tt.wantPublicKeyErr != nil && !errors.Is(err, tt.wantPublicKeyErr)) {
+				t.Errorf("PublicKey.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantPublicKeyErr)
+			}
+
+			if !reflect.DeepEqual(gotPublicKey, publicKey) {
+				t.Errorf("PublicKey.UnmarshalJSON() changed the key to %v on error", gotPublicKey)
+			}
+
+			gotPrivateKey := append(ecdh25519.PrivateKey(nil), privateKey...)
+			err = gotPrivateKey.UnmarshalJSON([]byte(tt.data))
+			if err == nil || (tt.wantPrivateKeyErr != nil && !errors.Is(err, tt.wantPrivateKeyErr)) {
+				t.Errorf("PrivateKey.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantPrivateKeyErr)
+			}
+
+			if !reflect.DeepEqual(gotPrivateKey, privateKey) {
+				t.Errorf("PrivateKey.UnmarshalJSON() changed the key on error")
+			}
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package ecdh25519
+
+import (
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+var (
+	initiatorToResponderInfo = []byte("ecdh25519 initiator to responder")
+	responderToInitiatorInfo = []byte("ecdh25519 responder to initiator")
+	sessionIDInfo            = []byte("ecdh25519 session id")
+	pseudonymInfo            = []byte("ecdh25519 pseudonym ")
+	contextInfo              = []byte("ecdh25519 context ")
+)
+
+const (
+	// sessionIDSize is the size, in bytes, of session identifiers before hex encoding.
+	sessionIDSize = 8
+	// PseudonymSize is the size, in bytes, of pseudonyms as used in this package.
+	PseudonymSize = 32
+	// SaltSize is the size, in bytes, of salts generated by DeriveKeyAutoSalt.
+	SaltSize = 32
+	// MaxDerivedKeySize is the largest key, in bytes, that HKDF-SHA256 can derive.
+	MaxDerivedKeySize = 255 * sha256.Size
+)
+
+var (
+	ErrBadDerivedKeyLength = errors.New("ecdh25519: bad derived key length")
+	ErrEmptyContext        = errors.New("ecdh25519: empty context")
+	ErrEmptySharedSecret   = errors.New("ecdh25519: empty shared secret")
+	ErrNilPRF              = errors.New("ecdh25519: nil PRF or hash function")
+	ErrPRFNotStreamable    = errors.New("ecdh25519: PRF cannot produce a key stream")
+)
+
+func init() {
+	registerCapability("hkdf-sha256")
+}
+
+// PRF is a key derivation construction used by the derivation helpers in this
+// package. Callers bound by compliance rules can plug in an approved function,
+// such as a particular HMAC or CMAC based KDF, in place of the default.
+type PRF interface {
+	// Derive fills dst with key material derived from secret, salt and info.
+	Derive(dst, secret, salt, info []byte) error
+}
+
+// HKDFSHA256 is the default PRF: HKDF with SHA-256, as defined in RFC 5869.
+var HKDFSHA256 PRF = hkdfHash(sha256.New)
+
+// DeriveDirectionalKeys derives a pair of 32-byte keys for the two directions of
+// a channel from a shared secret. Each direction uses its own HKDF-SHA256 label,
+// or a label under the KDF chosen with opts, and the keys are swapped according
+// to the role, so the initiator's send key is the responder's receive key and
+// vice versa.
+func DeriveDirectionalKeys(secret []byte, initiator bool, opts ...DeriveOption) (sendKey, recvKey [32]byte, err error) {
+	c, err := resolveDeriveOptions(opts)
+	if err != nil {
+		return [32]byte{}, [32]byte{}, err
+	}
+
+	if err := c.prf.Derive(sendKey[:], secret, nil, initiatorToResponderInfo); err != nil {
+		return [32]byte{}, [32]byte{}, err
+	}
+
+	if err := c.prf.Derive(recvKey[:], secret, nil, responderToInitiatorInfo); err != nil {
+		return [32]byte{}, [32]byte{}, err
+	}
+
+	if !initiator {
+		sendKey, recvKey = recvKey, sendKey
+	}
+
+	return sendKey, recvKey, nil
+}
+
+// SessionID returns a hex-encoded identifier derived from a shared secret, for
+// correlating the two sides of a session in logs. It is derived with
+// HKDF-SHA256, or the KDF chosen with opts, under its own label, so it reveals
+// nothing about keys derived from the same secret. It returns the empty string
+// if the KDF fails.
+func SessionID(secret []byte, opts ...DeriveOption) string {
+	c, err := resolveDeriveOptions(opts)
+	if err != nil {
+		return ""
+	}
+
+	id := make([]byte, sessionIDSize)
+	if err := c.prf.Derive(id, secret, nil, sessionIDInfo); err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(id)
+}
+
+// DeriveOption configures the KDF used by the derivation functions in this
+// package, which default to HKDF-SHA256.
+type DeriveOption func(*deriveConfig)
+
+type deriveConfig struct {
+	prf PRF
+}
+
+// WithHash selects HKDF with the hash function h, such as sha512.New, instead
+// of SHA-256. The maximum key length becomes 255 times the hash size. If h is
+// nil, the derivation fails with ErrNilPRF.
+func WithHash(h func() hash.Hash) DeriveOption {
+	return func(c *deriveConfig) {
+		c.prf = nil
+		if h != nil {
+			c.prf = hkdfHash(h)
+		}
+	}
+}
+
+// WithPRF selects prf instead of HKDF-SHA256, to match a protocol that
+// specifies a different KDF. Key lengths are not bounded beyond what prf itself
+// rejects. If prf is nil, the derivation fails with ErrNilPRF.
+func WithPRF(prf PRF) DeriveOption {
+	return func(c *deriveConfig) {
+		c.prf = prf
+	}
+}
+
+// resolveDeriveOptions applies opts over the HKDF-SHA256 default. If several
+// options are given, the last one wins.
+func resolveDeriveOptions(opts []DeriveOption) (deriveConfig, error) {
+	c := deriveConfig{prf: HKDFSHA256}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	if c.prf == nil {
+		return deriveConfig{}, ErrNilPRF
+	}
+
+	return c, nil
+}
+
+// maxLength returns the longest key c can derive, or 0 if the PRF sets its
+// own bound.
+func (c deriveConfig) maxLength() int {
+	if h, ok := c.prf.(hkdfHash); ok {
+		return 255 * h().Size()
+	}
+
+	return 0
+}
+
+// deriveKey derives a key of length bytes with the PRF of c.
+func (c deriveConfig) deriveKey(secret, salt, info []byte, length int) ([]byte, error) {
+	if limit := c.maxLength(); length < 1 || (limit > 0 && length > limit) {
+		return nil, fmt.Errorf("%w: %d", ErrBadDerivedKeyLength, length)
+	}
+
+	key := make([]byte, length)
+	if err := c.prf.Derive(key, secret, salt, info); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+type hkdfHash func() hash.Hash
+
+func (h hkdfHash) Derive(dst, secret, salt, info []byte) error {
+	_, err := io.ReadFull(hkdf.New(h, secret, salt, info), dst)
+	return err
+}
+
+// DeriveKey derives a key of length bytes from a shared secret using
+// HKDF-SHA256 with the given salt and info, as defined in RFC 5869. The raw
+// output of GenerateSharedSecret should go through DeriveKey, or a similar
+// KDF, before being used as a symmetric key.
+//
+// The KDF can be changed with WithHash or WithPRF. If several options are
+// given, the last one wins.
+func DeriveKey(sharedSecret, salt, info []byte, length int, opts ...DeriveOption) ([]byte, error) {
+	c, err := resolveDeriveOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.deriveKey(sharedSecret, salt, info, length)
+}
+
+// NewKeyStream returns a reader of HKDF-SHA256 output for sharedSecret, salt
+// and info, for deriving several keys from one exchange by reading successive
+// chunks, such as an encryption key, a MAC key and an IV. The reader returns
+// io.EOF once MaxDerivedKeySize bytes have been read, the most HKDF-SHA256 can
+// produce. It returns ErrEmptySharedSecret if sharedSecret is empty.
+//
+// WithHash changes the hash and the cap accordingly. A PRF chosen with WithPRF
+// has no streaming form, so NewKeyStream returns ErrPRFNotStreamable for it
+// unless it is HKDFSHA256.
+func NewKeyStream(sharedSecret, salt, info []byte, opts ...DeriveOption) (io.Reader, error) {
+	if len(sharedSecret) == 0 {
+		return nil, ErrEmptySharedSecret
+	}
+
+	c, err := resolveDeriveOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	h, ok := c.prf.(hkdfHash)
+	if !ok {
+		return nil, ErrPRFNotStreamable
+	}
+
+	return io.LimitReader(hkdf.New(h, sharedSecret, salt, info), int64(c.maxLength())), nil
+}
+
+// DeriveKeyWithContext derives a key of length bytes from a shared secret using
+// HKDF-SHA256, with context as the info parameter behind a prefix specific to
+// this package. Keys derived for different contexts, such as "encryption" and
+// "mac", are independent, and do not collide with keys other protocols derive
+// from the same secret. It returns ErrEmptyContext if context is empty. The KDF
+// can be changed with opts, as for DeriveKey.
+func DeriveKeyWithContext(sharedSecret []byte, context string, length int, opts ...DeriveOption) ([]byte, error) {
+	if context == "" {
+		return nil, ErrEmptyContext
+	}
+
+	info := make([]byte, 0, len(contextInfo)+len(context))
+	info = append(info, contextInfo...)
+	info = append(info, context...)
+
+	return DeriveKey(sharedSecret, nil, info, length, opts...)
+}
+
+// DeriveKeyAutoSalt generates a fresh random salt and derives a key of length
+// bytes from the shared secret between privateKey and publicKey with
+// HKDF-SHA256, or the KDF chosen with opts, using the salt and info.
+//
+// The salt is not secret, but it must be sent to the peer: the peer can only
+// derive the same key by calling DeriveKey on its own shared secret with the
+// same salt, info and options.
+func DeriveKeyAutoSalt(privateKey PrivateKey, publicKey PublicKey, info []byte, length int, opts ...DeriveOption) (salt, key []byte, err error) {
+	c, err := resolveDeriveOptions(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if limit := c.maxLength(); length < 1 || (limit > 0 && length > limit) {
+		return nil, nil, fmt.Errorf("%w: %d", ErrBadDerivedKeyLength, length)
+	}
+
+	sharedSecret, err := GenerateSharedSecret(privateKey, publicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer zeroize(sharedSecret)
+
+	salt = make([]byte, SaltSize)
+	if _, err := io.ReadFull(cryptorand.Reader, salt); err != nil {
+		return nil, nil, err
+	}
+
+	key, err = c.deriveKey(sharedSecret, salt, info, length)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return salt, key, nil
+}
+
+// Pseudonym returns an identifier derived from the private key with HKDF-SHA256,
+// or the KDF chosen with opts, for the given context. It is stable within one
+// context and unlinkable across contexts without knowledge of the private key.
+//
+// A pseudonym is not a public key and cannot be used for key agreement. It
+// returns nil if p is not PrivateKeySize bytes long, so malformed keys do not
+// all share one pseudonym, or if the KDF fails.
+func (p PrivateKey) Pseudonym(context []byte, opts ...DeriveOption) []byte {
+	if len(p) != PrivateKeySize {
+		return nil
+	}
+
+	c, err := resolveDeriveOptions(opts)
+	if err != nil {
+		return nil
+	}
+
+	info := make([]byte, 0, len(pseudonymInfo)+len(context))
+	info = append(info, pseudonymInfo...)
+	info = append(info, context...)
+
+	pseudonym, err := c.deriveKey(p, nil, info, PseudonymSize)
+	if err != nil {
+		return nil
+	}
+
+	return pseudonym
+}
+
+// hkdfExpand fills dst with HKDF-SHA256 output for the given secret, salt and info.
+func hkdfExpand(dst, secret, salt, info []byte) error {
+	_, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, info), dst)
+	return err
+}
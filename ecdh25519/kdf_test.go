@@ -0,0 +1,514 @@
+package ecdh25519_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestDeriveDirectionalKeys(t *testing.T) {
+	secret := []byte("shared secret")
+
+	initiatorSend, initiatorRecv, err := ecdh25519.DeriveDirectionalKeys(secret, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	responderSend, responderRecv, err := ecdh25519.DeriveDirectionalKeys(secret, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if initiatorSend != responderRecv {
+		t.Errorf("initiator send key = %x, responder receive key = %x", initiatorSend, responderRecv)
+	}
+
+	if initiatorRecv != responderSend {
+		t.Errorf("initiator receive key = %x, responder send key = %x", initiatorRecv, responderSend)
+	}
+
+	if initiatorSend == initiatorRecv {
+		t.Errorf("DeriveDirectionalKeys() returned the same key for both directions")
+	}
+}
+
+func TestSessionID(t *testing.T) {
+	secret := []byte("shared secret")
+
+	got := ecdh25519.SessionID(secret)
+	if len(got) != 16 {
+		t.Errorf("SessionID() = %v, want 16 hex characters", got)
+	}
+
+	if again := ecdh25519.SessionID(secret); got != again {
+		t.Errorf("SessionID() = %v, then %v", got, again)
+	}
+
+	if other := ecdh25519.SessionID([]byte("other secret")); got == other {
+		t.Errorf("SessionID() returned the same identifier for different secrets")
+	}
+}
+
+type stubPRF struct {
+	calls int
+}
+
+func (p *stubPRF) Derive(dst, secret, salt, info []byte) error {
+	p.calls++
+	for i := range dst {
+		dst[i] = byte(p.calls)
+	}
+
+	return nil
+}
+
+func TestDeriveDirectionalKeys_withPRF(t *testing.T) {
+	prf := &stubPRF{}
+
+	sendKey, recvKey, err := ecdh25519.DeriveDirectionalKeys([]byte("shared secret"), true, ecdh25519.WithPRF(prf))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if prf.calls != 2 {
+		t.Errorf("PRF.Derive() called %d times, want 2", prf.calls)
+	}
+
+	if sendKey[0] != 1 || recvKey[0] != 2 {
+		t.Errorf("DeriveDirectionalKeys() = %x, %x, want stub output", sendKey, recvKey)
+	}
+
+	want, _, err := ecdh25519.DeriveDirectionalKeys([]byte("shared secret"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, _, err := ecdh25519.DeriveDirectionalKeys([]byte("shared secret"), true, ecdh25519.WithPRF(ecdh25519.HKDFSHA256))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != want {
+		t.Errorf("DeriveDirectionalKeys() with HKDFSHA256 = %x, want %x", got, want)
+	}
+
+	if _, _, err := ecdh25519.DeriveDirectionalKeys([]byte("shared secret"), true, ecdh25519.WithHash(nil)); !errors.Is(err, ecdh25519.ErrNilPRF) {
+		t.Errorf("DeriveDirectionalKeys() with a nil hash error = %v, want %v", err, ecdh25519.ErrNilPRF)
+	}
+}
+
+func TestSessionID_withPRF(t *testing.T) {
+	if got, want := ecdh25519.SessionID([]byte("shared secret"), ecdh25519.WithPRF(&stubPRF{})), "0101010101010101"; got != want {
+		t.Errorf("SessionID() = %v, want %v", got, want)
+	}
+
+	if got, want := ecdh25519.SessionID([]byte("shared secret"), ecdh25519.WithPRF(ecdh25519.HKDFSHA256)), ecdh25519.SessionID([]byte("shared secret")); got != want {
+		t.Errorf("SessionID() with HKDFSHA256 = %v, want %v", got, want)
+	}
+
+	if got := ecdh25519.SessionID([]byte("shared secret"), ecdh25519.WithHash(nil)); got != "" {
+		t.Errorf("SessionID() with a nil hash = %v, want empty", got)
+	}
+}
+
+// test vectors from: https://www.rfc-editor.org/rfc/rfc5869#appendix-A.1, and
+// the same inputs with HKDF-SHA512
+func TestDeriveKey(t *testing.T) {
+	decode := func(s string) []byte {
+		t.Helper()
+
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return b
+	}
+
+	sharedSecret := decode("0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b")
+	salt := decode("000102030405060708090a0b0c")
+	info := decode("f0f1f2f3f4f5f6f7f8f9")
+
+	tests := []struct {
+		name string
+		opts []ecdh25519.DeriveOption
+		want []byte
+	}{
+		{
+			name: "default",
+			want: decode("3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865"),
+		},
+		{
+			name: "with sha256",
+			opts: []ecdh25519.DeriveOption{ecdh25519.WithHash(sha256.New)},
+			want: decode("3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865"),
+		},
+		{
+			name: "with sha512",
+			opts: []ecdh25519.DeriveOption{ecdh25519.WithHash(sha512.New)},
+			want: decode("832390086cda71fb47625bb5ceb168e4c8e26a1a16ed34d9fc7fe92c1481579338da362cb8d9f925d7cb"),
+		},
+		{
+			name: "with prf",
+			opts: []ecdh25519.DeriveOption{ecdh25519.WithPRF(ecdh25519.HKDFSHA256)},
+			want: decode("3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ecdh25519.DeriveKey(sharedSecret, salt, info, len(tt.want), tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DeriveKey() = %x, want %x", got, tt.want)
+			}
+		})
+	}
+
+	for _, length := range []int{0, -1, ecdh25519.MaxDerivedKeySize + 1} {
+		if _, err := ecdh25519.DeriveKey(sharedSecret, salt, info, length); !errors.Is(err, ecdh25519.ErrBadDerivedKeyLength) {
+			t.Errorf("DeriveKey() with length %d error = %v, want %v", length, err, ecdh25519.ErrBadDerivedKeyLength)
+		}
+	}
+
+	if _, err := ecdh25519.DeriveKey(sharedSecret, salt, info, ecdh25519.MaxDerivedKeySize); err != nil {
+		t.Errorf("DeriveKey() with maximum length error = %v, want nil", err)
+	}
+
+	if _, err := ecdh25519.DeriveKey(sharedSecret, salt, info, 255*sha512.Size, ecdh25519.WithHash(sha512.New)); err != nil {
+		t.Errorf("DeriveKey() with maximum sha512 length error = %v, want nil", err)
+	}
+
+	if _, err := ecdh25519.DeriveKey(sharedSecret, salt, info, 255*sha512.Size+1, ecdh25519.WithHash(sha512.New)); !errors.Is(err, ecdh25519.ErrBadDerivedKeyLength) {
+		t.Errorf("DeriveKey() with length over the sha512 maximum error = %v, want %v", err, ecdh25519.ErrBadDerivedKeyLength)
+	}
+}
+
+func TestDeriveOptions(t *testing.T) {
+	_, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicKey, err := privateKey.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret := []byte("shared secret")
+
+	tests := []struct {
+		name string
+		fn   func(opts ...ecdh25519.DeriveOption) ([]byte, error)
+	}{
+		{
+			name: "DeriveKey",
+			fn: func(opts ...ecdh25519.DeriveOption) ([]byte, error) {
+				return ecdh25519.DeriveKey(secret, nil, []byte("info"), 32, opts...)
+			},
+		},
+		{
+			name: "DeriveKeyWithContext",
+			fn: func(opts ...ecdh25519.DeriveOption) ([]byte, error) {
+				return ecdh25519.DeriveKeyWithContext(secret, "encryption", 32, opts...)
+			},
+		},
+		{
+			name: "DeriveKeyAutoSalt",
+			fn: func(opts ...ecdh25519.DeriveOption) ([]byte, error) {
+				salt, key, err := ecdh25519.DeriveKeyAutoSalt(privateKey, publicKey, nil, 32, opts...)
+				if err != nil {
+					return nil, err
+				}
+
+				sharedSecret, err := ecdh25519.GenerateSharedSecret(privateKey, publicKey)
+				if err != nil {
+					return nil, err
+				}
+
+				// The salt is random, so check the key against DeriveKey
+				// with the same options instead.
+				want, err := ecdh25519.DeriveKey(sharedSecret, salt, nil, 32, opts...)
+				if err != nil {
+					return nil, err
+				}
+
+				if !bytes.Equal(key, want) {
+					return nil, errors.New("key does not match DeriveKey")
+				}
+
+				return sharedSecret, nil
+			},
+		},
+		{
+			name: "NewKeyStream",
+			fn: func(opts ...ecdh25519.DeriveOption) ([]byte, error) {
+				stream, err := ecdh25519.NewKeyStream(secret, nil, []byte("info"), opts...)
+				if err != nil {
+					return nil, err
+				}
+
+				key := make([]byte, 32)
+				_, err = io.ReadFull(stream, key)
+				return key, err
+			},
+		},
+		{
+			name: "PrivateKey.Pseudonym",
+			fn: func(opts ...ecdh25519.DeriveOption) ([]byte, error) {
+				if pseudonym := privateKey.Pseudonym([]byte("forum.example"), opts...); pseudonym != nil {
+					return pseudonym, nil
+				}
+
+				return nil, ecdh25519.ErrNilPRF
+			},
+		},
+		{
+			name: "DeviceKeyPair",
+			fn: func(opts ...ecdh25519.DeriveOption) ([]byte, error) {
+				publicKey, _, err := ecdh25519.DeviceKeyPair([]byte("fingerprint"), []byte("salt"), opts...)
+				return publicKey, err
+			},
+		},
+		{
+			name: "FinalizeHandshake",
+			fn: func(opts ...ecdh25519.DeriveOption) ([]byte, error) {
+				encKey, confirmTag, err := ecdh25519.FinalizeHandshake(secret, []byte("transcript"), opts...)
+				return append(encKey[:], confirmTag...), err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def, err := tt.fn()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			withSHA256, err := tt.fn(ecdh25519.WithHash(sha256.New))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(withSHA256, def) {
+				t.Errorf("%s() with WithHash(sha256.New) = %x, want the default %x", tt.name, withSHA256, def)
+			}
+
+			withSHA512, err := tt.fn(ecdh25519.WithHash(sha512.New))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.name != "DeriveKeyAutoSalt" && bytes.Equal(withSHA512, def) {
+				t.Errorf("%s() with WithHash(sha512.New) = %x, want it to differ from the default", tt.name, withSHA512)
+			}
+
+			for _, opt := range []ecdh25519.DeriveOption{ecdh25519.WithHash(nil), ecdh25519.WithPRF(nil)} {
+				if _, err := tt.fn(opt); !errors.Is(err, ecdh25519.ErrNilPRF) {
+					t.Errorf("%s() with a nil PRF error = %v, want %v", tt.name, err, ecdh25519.ErrNilPRF)
+				}
+			}
+		})
+	}
+}
+
+func TestNewKeyStream(t *testing.T) {
+	sharedSecret := bytes.Repeat([]byte{0x0b}, 32)
+	salt := []byte("salt")
+	info := []byte("info")
+
+	stream, err := ecdh25519.NewKeyStream(sharedSecret, salt, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encryptionKey := make([]byte, 32)
+	macKey := make([]byte, 32)
+	for _, key := range [][]byte{encryptionKey, macKey} {
+		if _, err := io.ReadFull(stream, key); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := ecdh25519.DeriveKey(sharedSecret, salt, info, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := append(encryptionKey, macKey...); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewKeyStream() read %x, want %x", got, want)
+	}
+
+	rest, err := io.ReadAll(stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(rest) + 64; l != ecdh25519.MaxDerivedKeySize {
+		t.Errorf("NewKeyStream() yielded %d bytes, want %d", l, ecdh25519.MaxDerivedKeySize)
+	}
+
+	if n, err := stream.Read(make([]byte, 1)); n != 0 || err != io.EOF {
+		t.Errorf("NewKeyStream() Read() after the cap = %d, %v, want 0, %v", n, err, io.EOF)
+	}
+
+	if _, err := ecdh25519.NewKeyStream(nil, salt, info); !errors.Is(err, ecdh25519.ErrEmptySharedSecret) {
+		t.Errorf("NewKeyStream() error = %v, want %v", err, ecdh25519.ErrEmptySharedSecret)
+	}
+
+	if _, err := ecdh25519.NewKeyStream(sharedSecret, salt, info, ecdh25519.WithPRF(&stubPRF{})); !errors.Is(err, ecdh25519.ErrPRFNotStreamable) {
+		t.Errorf("NewKeyStream() with a custom PRF error = %v, want %v", err, ecdh25519.ErrPRFNotStreamable)
+	}
+
+	stream, err = ecdh25519.NewKeyStream(sharedSecret, salt, info, ecdh25519.WithHash(sha512.New))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rest, err := io.ReadAll(stream); err != nil || len(rest) != 255*sha512.Size {
+		t.Errorf("NewKeyStream() with sha512 yielded %d bytes, %v, want %d, nil", len(rest), err, 255*sha512.Size)
+	}
+}
+
+func TestDeriveKeyWithContext(t *testing.T) {
+	sharedSecret := bytes.Repeat([]byte{0x0b}, 32)
+
+	encryptionKey, err := ecdh25519.DeriveKeyWithContext(sharedSecret, "encryption", 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	again, err := ecdh25519.DeriveKeyWithContext(sharedSecret, "encryption", 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(encryptionKey, again) {
+		t.Errorf("DeriveKeyWithContext() = %x, then %x, want equal keys", encryptionKey, again)
+	}
+
+	macKey, err := ecdh25519.DeriveKeyWithContext(sharedSecret, "mac", 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reflect.DeepEqual(encryptionKey, macKey) {
+		t.Errorf("DeriveKeyWithContext() = %x for two contexts, want distinct keys", macKey)
+	}
+
+	rawKey, err := ecdh25519.DeriveKey(sharedSecret, nil, []byte("encryption"), 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reflect.DeepEqual(encryptionKey, rawKey) {
+		t.Errorf("DeriveKeyWithContext() = %x, want it to differ from DeriveKey with the bare context", encryptionKey)
+	}
+
+	if _, err := ecdh25519.DeriveKeyWithContext(sharedSecret, "", 32); !errors.Is(err, ecdh25519.ErrEmptyContext) {
+		t.Errorf("DeriveKeyWithContext() error = %v, want %v", err, ecdh25519.ErrEmptyContext)
+	}
+
+	if _, err := ecdh25519.DeriveKeyWithContext(sharedSecret, "mac", 0); !errors.Is(err, ecdh25519.ErrBadDerivedKeyLength) {
+		t.Errorf("DeriveKeyWithContext() error = %v, want %v", err, ecdh25519.ErrBadDerivedKeyLength)
+	}
+}
+
+func TestDeriveKeyAutoSalt(t *testing.T) {
+	alicePublicKey, alicePrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bobPublicKey, bobPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info := []byte("example")
+
+	salt, key, err := ecdh25519.DeriveKeyAutoSalt(alicePrivateKey, bobPublicKey, info, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(salt) != ecdh25519.SaltSize || len(key) != 32 {
+		t.Fatalf("DeriveKeyAutoSalt() salt and key lengths = %d, %d, want %d, 32", len(salt), len(key), ecdh25519.SaltSize)
+	}
+
+	bobSharedSecret, err := ecdh25519.GenerateSharedSecret(bobPrivateKey, alicePublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bobKey, err := ecdh25519.DeriveKey(bobSharedSecret, salt, info, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(key, bobKey) {
+		t.Errorf("DeriveKeyAutoSalt() key = %x, peer key = %x", key, bobKey)
+	}
+
+	otherSalt, _, err := ecdh25519.DeriveKeyAutoSalt(alicePrivateKey, bobPublicKey, info, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reflect.DeepEqual(salt, otherSalt) {
+		t.Errorf("DeriveKeyAutoSalt() returned the same salt twice")
+	}
+
+	for _, length := range []int{0, ecdh25519.MaxDerivedKeySize + 1} {
+		if _, _, err := ecdh25519.DeriveKeyAutoSalt(alicePrivateKey, bobPublicKey, info, length); !errors.Is(err, ecdh25519.ErrBadDerivedKeyLength) {
+			t.Errorf("DeriveKeyAutoSalt() with length %d error = %v, want %v", length, err, ecdh25519.ErrBadDerivedKeyLength)
+		}
+	}
+}
+
+func TestPrivateKey_Pseudonym(t *testing.T) {
+	_, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := privateKey.Pseudonym([]byte("forum.example"))
+	if len(got) != ecdh25519.PseudonymSize {
+		t.Fatalf("PrivateKey.Pseudonym() length = %d, want %d", len(got), ecdh25519.PseudonymSize)
+	}
+
+	if again := privateKey.Pseudonym([]byte("forum.example")); !reflect.DeepEqual(got, again) {
+		t.Errorf("PrivateKey.Pseudonym() = %x, then %x", got, again)
+	}
+
+	if other := privateKey.Pseudonym([]byte("shop.example")); reflect.DeepEqual(got, other) {
+		t.Errorf("PrivateKey.Pseudonym() returned the same pseudonym for different contexts")
+	}
+
+	_, otherPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if other := otherPrivateKey.Pseudonym([]byte("forum.example")); reflect.DeepEqual(got, other) {
+		t.Errorf("PrivateKey.Pseudonym() returned the same pseudonym for different keys")
+	}
+
+	for _, malformed := range []ecdh25519.PrivateKey{nil, privateKey[:1], privateKey[:16]} {
+		if got := malformed.Pseudonym([]byte("forum.example")); got != nil {
+			t.Errorf("PrivateKey.Pseudonym() with %d-byte key = %x, want nil", len(malformed), got)
+		}
+	}
+}
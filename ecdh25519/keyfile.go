@@ -0,0 +1,99 @@
+package ecdh25519
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// PrivateKeyFileName is the name of the private key file written by GenerateKeyPairToDir.
+	PrivateKeyFileName = "key"
+	// PublicKeyFileName is the name of the public key file written by GenerateKeyPairToDir.
+	PublicKeyFileName = "key.pub"
+)
+
+// GenerateKeyPairToDir generates a public/private key pair using entropy from
+// rand and writes it to dir as PEM files: PrivateKeyFileName, readable only by
+// the owner, and PublicKeyFileName, readable by everyone. If rand is nil,
+// crypto/rand.Reader will be used.
+//
+// Each file is written to a temporary file first and then linked into place, so
+// readers never observe a partial key. It returns an error without writing
+// anything if either file already exists.
+func GenerateKeyPairToDir(dir string, rand io.Reader) (*KeyPair, error) {
+	privateKeyPath := filepath.Join(dir, PrivateKeyFileName)
+	publicKeyPath := filepath.Join(dir, PublicKeyFileName)
+
+	for _, path := range []string{privateKeyPath, publicKeyPath} {
+		if _, err := os.Lstat(path); err == nil {
+			return nil, fmt.Errorf("ecdh25519: %s: %w", path, os.ErrExist)
+		} else if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	publicKey, privateKey, err := GenerateKeyPair(rand)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKeyPEM, err := privateKey.MarshalPEM()
+	if err != nil {
+		return nil, err
+	}
+
+	defer zeroize(privateKeyPEM)
+
+	if err := writeFileExclusive(privateKeyPath, privateKeyPEM, 0o600); err != nil {
+		return nil, err
+	}
+
+	publicKeyPEM, err := publicKey.MarshalPEM()
+	if err != nil {
+		os.Remove(privateKeyPath)
+		return nil, err
+	}
+
+	if err := writeFileExclusive(publicKeyPath, publicKeyPEM, 0o644); err != nil {
+		os.Remove(privateKeyPath)
+		return nil, err
+	}
+
+	return &KeyPair{PublicKey: publicKey, PrivateKey: privateKey}, nil
+}
+
+// writeFileExclusive atomically writes data to path with the given permissions,
+// failing if path already exists.
+func writeFileExclusive(path string, data []byte, perm os.FileMode) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	tmp := f.Name()
+	defer os.Remove(tmp)
+
+	if err := f.Chmod(perm); err != nil {
+		f.Close()
+		return err
+	}
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	// Unlike a rename, a link never replaces an existing file.
+	return os.Link(tmp, path)
+}
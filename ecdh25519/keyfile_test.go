@@ -0,0 +1,84 @@
+package ecdh25519_test
+
+import (
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestGenerateKeyPairToDir(t *testing.T) {
+	dir := t.TempDir()
+
+	keyPair, err := ecdh25519.GenerateKeyPairToDir(dir, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		blockType string
+		want      []byte
+		wantPerm  os.FileMode
+	}{
+		{
+			name:      ecdh25519.PrivateKeyFileName,
+			blockType: ecdh25519.PrivateKeyPEMType,
+			want:      keyPair.PrivateKey,
+			wantPerm:  0o600,
+		},
+		{
+			name:      ecdh25519.PublicKeyFileName,
+			blockType: ecdh25519.PublicKeyPEMType,
+			want:      keyPair.PublicKey,
+			wantPerm:  0o644,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name)
+
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if perm := info.Mode().Perm(); perm != tt.wantPerm {
+				t.Errorf("%s permissions = %v, want %v", tt.name, perm, tt.wantPerm)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			block, _ := pem.Decode(data)
+			if block == nil || block.Type != tt.blockType {
+				t.Fatalf("%s does not contain a %s block", tt.name, tt.blockType)
+			}
+
+			if !reflect.DeepEqual(block.Bytes, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, block.Bytes, tt.want)
+			}
+		})
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 2 {
+		t.Errorf("GenerateKeyPairToDir() left %d files, want 2", len(entries))
+	}
+
+	if _, err := ecdh25519.GenerateKeyPairToDir(dir, rand.Reader); !errors.Is(err, os.ErrExist) {
+		t.Errorf("GenerateKeyPairToDir() error = %v, want %v", err, os.ErrExist)
+	}
+}
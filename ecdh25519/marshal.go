@@ -0,0 +1,112 @@
+package ecdh25519
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+var ErrMalformedHexKey = errors.New("ecdh25519: malformed hex key")
+
+// MarshalBinary implements encoding.BinaryMarshaler. It returns a copy of the
+// key bytes.
+func (p PublicKey) MarshalBinary() ([]byte, error) {
+	if l := len(p); l != PublicKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	return append([]byte(nil), p...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (p *PublicKey) UnmarshalBinary(data []byte) error {
+	if l := len(data); l != PublicKeySize {
+		return fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	*p = append(PublicKey(nil), data...)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. It returns a copy of the
+// key bytes.
+func (p PrivateKey) MarshalBinary() ([]byte, error) {
+	if l := len(p); l != PrivateKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	return append([]byte(nil), p...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (p *PrivateKey) UnmarshalBinary(data []byte) error {
+	if l := len(data); l != PrivateKeySize {
+		return fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	*p = append(PrivateKey(nil), data...)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler. The key is hex encoded.
+func (p PublicKey) MarshalText() ([]byte, error) {
+	if l := len(p); l != PublicKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	return hexEncode(p), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *PublicKey) UnmarshalText(text []byte) error {
+	b, err := hexDecode(text)
+	if err != nil {
+		return err
+	}
+
+	if l := len(b); l != PublicKeySize {
+		return fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	*p = b
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler. The key is hex encoded.
+func (p PrivateKey) MarshalText() ([]byte, error) {
+	if l := len(p); l != PrivateKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	return hexEncode(p), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *PrivateKey) UnmarshalText(text []byte) error {
+	b, err := hexDecode(text)
+	if err != nil {
+		return err
+	}
+
+	if l := len(b); l != PrivateKeySize {
+		return fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	*p = b
+	return nil
+}
+
+func hexEncode(b []byte) []byte {
+	text := make([]byte, hex.EncodedLen(len(b)))
+	hex.Encode(text, b)
+	return text
+}
+
+func hexDecode(text []byte) ([]byte, error) {
+	b := make([]byte, hex.DecodedLen(len(text)))
+	if _, err := hex.Decode(b, text); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedHexKey, err)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,144 @@
+package ecdh25519_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestPublicKey_MarshalBinary(t *testing.T) {
+	publicKey, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type keys struct {
+		PublicKey  ecdh25519.PublicKey
+		PrivateKey ecdh25519.PrivateKey
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(keys{PublicKey: publicKey, PrivateKey: privateKey}); err != nil {
+		t.Fatal(err)
+	}
+
+	var got keys
+	if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got.PublicKey, publicKey) || !reflect.DeepEqual(got.PrivateKey, privateKey) {
+		t.Errorf("gob round trip = %v, want %v", got, keys{PublicKey: publicKey, PrivateKey: privateKey})
+	}
+
+	b, err := publicKey.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b[0] ^= 1
+	if b[0] == publicKey[0] {
+		t.Errorf("PublicKey.MarshalBinary() returned the key's backing array")
+	}
+}
+
+func TestPublicKey_UnmarshalBinary(t *testing.T) {
+	var publicKey ecdh25519.PublicKey
+	if err := publicKey.UnmarshalBinary(make([]byte, 16)); !errors.Is(err, ecdh25519.ErrBadPublicKeyLength) {
+		t.Errorf("PublicKey.UnmarshalBinary() error = %v, want %v", err, ecdh25519.ErrBadPublicKeyLength)
+	}
+
+	var privateKey ecdh25519.PrivateKey
+	if err := privateKey.UnmarshalBinary(make([]byte, 16)); !errors.Is(err, ecdh25519.ErrBadPrivateKeyLength) {
+		t.Errorf("PrivateKey.UnmarshalBinary() error = %v, want %v", err, ecdh25519.ErrBadPrivateKeyLength)
+	}
+
+	data := make([]byte, ecdh25519.PublicKeySize)
+	if err := publicKey.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	data[0] = 1
+	if publicKey[0] != 0 {
+		t.Errorf("PublicKey.UnmarshalBinary() aliases its input")
+	}
+}
+
+func TestPublicKey_MarshalText(t *testing.T) {
+	publicKey, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text, err := publicKey.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := hex.EncodeToString(publicKey); string(text) != want {
+		t.Errorf("PublicKey.MarshalText() = %s, want %s", text, want)
+	}
+
+	privateText, err := privateKey.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gotPublicKey ecdh25519.PublicKey
+	var gotPrivateKey ecdh25519.PrivateKey
+
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.TextVar(&gotPublicKey, "public-key", ecdh25519.PublicKey(nil), "")
+	set.TextVar(&gotPrivateKey, "private-key", ecdh25519.PrivateKey(nil), "")
+	if err := set.Parse([]string{"-public-key", string(text), "-private-key", string(privateText)}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(gotPublicKey, publicKey) || !reflect.DeepEqual(gotPrivateKey, privateKey) {
+		t.Errorf("flag round trip = %v, %v, want %v, %v", gotPublicKey, gotPrivateKey, publicKey, privateKey)
+	}
+}
+
+func TestPublicKey_UnmarshalText(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		wantErr error
+	}{
+		{
+			name: "valid",
+			text: "de9edb7d7b7dc1b4d35b61c2ece435373f8343c85b78674dadfc7e146f882b4f",
+		},
+		{
+			name:    "with short key",
+			text:    "de9edb7d",
+			wantErr: ecdh25519.ErrBadPublicKeyLength,
+		},
+		{
+			name:    "with bad hex",
+			text:    "not hex",
+			wantErr: ecdh25519.ErrMalformedHexKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var publicKey ecdh25519.PublicKey
+			if err := publicKey.UnmarshalText([]byte(tt.text)); !errors.Is(err, tt.wantErr) {
+				t.Errorf("PublicKey.UnmarshalText() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	var privateKey ecdh25519.PrivateKey
+	if err := privateKey.UnmarshalText([]byte("00")); !errors.Is(err, ecdh25519.ErrBadPrivateKeyLength) {
+		t.Errorf("PrivateKey.UnmarshalText() error = %v, want %v", err, ecdh25519.ErrBadPrivateKeyLength)
+	}
+}
@@ -0,0 +1,426 @@
+package ecdh25519_test
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+// TestNilInputs checks that nil and short keys are rejected with the package's
+// length errors rather than a panic or an error from a lower layer.
+func TestNilInputs(t *testing.T) {
+	publicKey, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, in := range []struct {
+		name       string
+		privateKey ecdh25519.PrivateKey
+		publicKey  ecdh25519.PublicKey
+	}{
+		{name: "nil"},
+		{name: "short", privateKey: privateKey[:16], publicKey: publicKey[:16]},
+	} {
+		tests := []struct {
+			name    string
+			fn      func() error
+			wantErr error
+		}{
+			{
+				name: "PrivateKey.PublicKey",
+				fn: func() error {
+					_, err := in.privateKey.PublicKey()
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPrivateKeyLength,
+			},
+			{
+				name: "PrivateKey.MatchesPublicKey",
+				fn: func() error {
+					_, err := in.privateKey.MatchesPublicKey(publicKey)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPrivateKeyLength,
+			},
+			{
+				name: "PrivateKey.MatchesPublicKey public key",
+				fn: func() error {
+					_, err := privateKey.MatchesPublicKey(in.publicKey)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "ScalarMult",
+				fn: func() error {
+					_, err := ecdh25519.ScalarMult(privateKey, in.publicKey)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "NewPrivateKey",
+				fn: func() error {
+					_, err := ecdh25519.NewPrivateKey(in.privateKey)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPrivateKeyLength,
+			},
+			{
+				name: "ParsePublicKey",
+				fn: func() error {
+					_, err := ecdh25519.ParsePublicKey(in.publicKey)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "ParsePrivateKey",
+				fn: func() error {
+					_, err := ecdh25519.ParsePrivateKey(in.privateKey)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPrivateKeyLength,
+			},
+			{
+				name: "GenerateSharedSecret private key",
+				fn: func() error {
+					_, err := ecdh25519.GenerateSharedSecret(in.privateKey, publicKey)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPrivateKeyLength,
+			},
+			{
+				name: "GenerateSharedSecret public key",
+				fn: func() error {
+					_, err := ecdh25519.GenerateSharedSecret(privateKey, in.publicKey)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "GenerateSharedSecretInto",
+				fn: func() error {
+					return ecdh25519.GenerateSharedSecretInto(make([]byte, ecdh25519.SharedSecretSize), privateKey, in.publicKey)
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "GenerateSharedSecretStrict",
+				fn: func() error {
+					_, err := ecdh25519.GenerateSharedSecretStrict(privateKey, in.publicKey)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "GenerateSharedSecretTimed",
+				fn: func() error {
+					_, _, err := ecdh25519.GenerateSharedSecretTimed(in.privateKey, publicKey)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPrivateKeyLength,
+			},
+			{
+				name: "GenerateSharedSecrets",
+				fn: func() error {
+					_, err := ecdh25519.GenerateSharedSecrets(in.privateKey, []ecdh25519.PublicKey{publicKey})
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPrivateKeyLength,
+			},
+			{
+				name: "SharedSecretSelect",
+				fn: func() error {
+					_, err := ecdh25519.SharedSecretSelect([]ecdh25519.PrivateKey{in.privateKey}, publicKey, 0)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPrivateKeyLength,
+			},
+			{
+				name: "EphemeralSharedSecret",
+				fn: func() error {
+					_, _, err := ecdh25519.EphemeralSharedSecret(rand.Reader, in.publicKey)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "NewPrecomputedPeer",
+				fn: func() error {
+					_, err := ecdh25519.NewPrecomputedPeer(in.publicKey)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "NewAgreement",
+				fn: func() error {
+					_, err := ecdh25519.NewAgreement(in.privateKey)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPrivateKeyLength,
+			},
+			{
+				name: "NewPeerTable",
+				fn: func() error {
+					_, err := ecdh25519.NewPeerTable(in.privateKey)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPrivateKeyLength,
+			},
+			{
+				name: "NewAEAD",
+				fn: func() error {
+					_, err := ecdh25519.NewAEAD(privateKey, in.publicKey)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "SealBox",
+				fn: func() error {
+					_, err := ecdh25519.SealBox([]byte("message"), in.publicKey, privateKey)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "DeriveKeyAutoSalt",
+				fn: func() error {
+					_, _, err := ecdh25519.DeriveKeyAutoSalt(in.privateKey, publicKey, nil, 32)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPrivateKeyLength,
+			},
+			{
+				name: "PublicKey.MarshalBinary",
+				fn: func() error {
+					_, err := in.publicKey.MarshalBinary()
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "PrivateKey.MarshalText",
+				fn: func() error {
+					_, err := in.privateKey.MarshalText()
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPrivateKeyLength,
+			},
+			{
+				name: "PublicKey.MarshalPEM",
+				fn: func() error {
+					_, err := in.publicKey.MarshalPEM()
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "MarshalRawPublicKey",
+				fn: func() error {
+					_, err := ecdh25519.MarshalRawPublicKey(in.publicKey)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "MarshalOpenSSHPublicKey",
+				fn: func() error {
+					_, err := ecdh25519.MarshalOpenSSHPublicKey(in.publicKey, "")
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "MarshalEncrypted",
+				fn: func() error {
+					_, err := ecdh25519.MarshalEncrypted(in.privateKey, []byte("passphrase"))
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPrivateKeyLength,
+			},
+			{
+				name: "PublicKey.WriteTo",
+				fn: func() error {
+					_, err := in.publicKey.WriteTo(nil)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "PublicKey.Validate",
+				fn: func() error {
+					return in.publicKey.Validate()
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "PrivateKey.ToStdlib",
+				fn: func() error {
+					_, err := in.privateKey.ToStdlib()
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPrivateKeyLength,
+			},
+			{
+				name: "PublicKey.ToStdlib",
+				fn: func() error {
+					_, err := in.publicKey.ToStdlib()
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "GenerateSharedSecretConsuming",
+				fn: func() error {
+					// The function zeroizes the public key, and in.publicKey
+					// aliases publicKey.
+					_, err := ecdh25519.GenerateSharedSecretConsuming(privateKey, append(ecdh25519.PublicKey(nil), in.publicKey...))
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "GenerateSharedSecretChecked",
+				fn: func() error {
+					_, err := ecdh25519.GenerateSharedSecretChecked(in.privateKey, publicKey, nil)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPrivateKeyLength,
+			},
+			{
+				name: "RotateKeyPair",
+				fn: func() error {
+					_, _, err := ecdh25519.RotateKeyPair(in.privateKey, rand.Reader)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPrivateKeyLength,
+			},
+			{
+				name: "ThreeParty.Round2",
+				fn: func() error {
+					_, err := ecdh25519.ThreeParty{}.Round2(privateKey, in.publicKey)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "MaskPublicKey",
+				fn: func() error {
+					_, err := ecdh25519.MaskPublicKey(in.publicKey, make([]byte, ecdh25519.BlindSize))
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "UnmaskSharedSecret",
+				fn: func() error {
+					_, err := ecdh25519.UnmaskSharedSecret(in.privateKey, publicKey)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPrivateKeyLength,
+			},
+			{
+				name: "SealHandshake private key",
+				fn: func() error {
+					_, err := ecdh25519.SealHandshake(in.privateKey, publicKey, []byte("message"), nil)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPrivateKeyLength,
+			},
+			{
+				name: "SealHandshake public key",
+				fn: func() error {
+					_, err := ecdh25519.SealHandshake(privateKey, in.publicKey, []byte("message"), nil)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "OpenHandshake",
+				fn: func() error {
+					_, err := ecdh25519.OpenHandshake(in.privateKey, make([]byte, 64), nil)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPrivateKeyLength,
+			},
+			{
+				name: "ClientHandshake",
+				fn: func() error {
+					_, _, err := ecdh25519.ClientHandshake(rand.Reader, in.publicKey, nil)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "ServerHandshake private key",
+				fn: func() error {
+					_, err := ecdh25519.ServerHandshake(in.privateKey, publicKey, nil)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPrivateKeyLength,
+			},
+			{
+				name: "ServerHandshake public key",
+				fn: func() error {
+					_, err := ecdh25519.ServerHandshake(privateKey, in.publicKey, nil)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "Commit",
+				fn: func() error {
+					_, _, err := ecdh25519.Commit(in.publicKey)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "VerifyFingerprint",
+				fn: func() error {
+					_, err := ecdh25519.VerifyFingerprint(in.publicKey, publicKey.Fingerprint())
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+			{
+				name: "PublicKeyFromProtoBytes",
+				fn: func() error {
+					_, err := ecdh25519.PublicKeyFromProtoBytes(in.publicKey)
+					return err
+				},
+				wantErr: ecdh25519.ErrBadPublicKeyLength,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(in.name+"/"+tt.name, func(t *testing.T) {
+				if err := tt.fn(); !errors.Is(err, tt.wantErr) {
+					t.Errorf("%s() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+				}
+			})
+		}
+
+		t.Run(in.name+"/PrivateKey.Pseudonym", func(t *testing.T) {
+			if got := in.privateKey.Pseudonym(nil); got != nil {
+				t.Errorf("PrivateKey.Pseudonym() = %x, want nil", got)
+			}
+		})
+
+		t.Run(in.name+"/PublicKey.Fingerprint", func(t *testing.T) {
+			if got := in.publicKey.Fingerprint(); got != "" {
+				t.Errorf("PublicKey.Fingerprint() = %v, want empty", got)
+			}
+
+			if got := in.publicKey.FingerprintBytes(); got != nil {
+				t.Errorf("PublicKey.FingerprintBytes() = %x, want nil", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package ecdh25519
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// OpenSSHKeyType is the key type name used for X25519 public keys in the
+// OpenSSH format. OpenSSH itself does not define a type for X25519 keys, so
+// this name is a convention shared with other tooling, not a standard.
+const OpenSSHKeyType = "ssh-x25519"
+
+var ErrMalformedOpenSSHPublicKey = errors.New("ecdh25519: malformed openssh public key")
+
+func init() {
+	registerCapability("openssh")
+}
+
+// openSSHPublicKey is the SSH wire format of a public key, RFC 4253, section 6.6.
+type openSSHPublicKey struct {
+	Type string
+	Key  []byte
+	Rest []byte `ssh:"rest"`
+}
+
+// MarshalOpenSSHPublicKey encodes a public key as a line in the OpenSSH
+// authorized_keys format: the key type, the base64 encoded SSH wire format of
+// the key and, if not empty, comment. The line ends with a newline.
+func MarshalOpenSSHPublicKey(publicKey PublicKey, comment string) ([]byte, error) {
+	if l := len(publicKey); l != PublicKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	wire := ssh.Marshal(openSSHPublicKey{Type: OpenSSHKeyType, Key: publicKey})
+
+	b := new(bytes.Buffer)
+	b.WriteString(OpenSSHKeyType)
+	b.WriteByte(' ')
+	b.WriteString(base64.StdEncoding.EncodeToString(wire))
+	if comment != "" {
+		b.WriteByte(' ')
+		b.WriteString(comment)
+	}
+	b.WriteByte('\n')
+
+	return b.Bytes(), nil
+}
+
+// ParseOpenSSHPublicKey parses a public key from a line in the OpenSSH
+// authorized_keys format, as produced by MarshalOpenSSHPublicKey, and returns
+// it along with its comment. Options before the key type are not supported.
+func ParseOpenSSHPublicKey(data []byte) (PublicKey, string, error) {
+	fields := bytes.SplitN(bytes.TrimSpace(data), []byte(" "), 3)
+	if len(fields) < 2 || string(fields[0]) != OpenSSHKeyType {
+		return nil, "", ErrMalformedOpenSSHPublicKey
+	}
+
+	wire, err := base64.StdEncoding.DecodeString(string(fields[1]))
+	if err != nil {
+		return nil, "", ErrMalformedOpenSSHPublicKey
+	}
+
+	var key openSSHPublicKey
+	if err := ssh.Unmarshal(wire, &key); err != nil || key.Type != OpenSSHKeyType || len(key.Rest) != 0 {
+		return nil, "", ErrMalformedOpenSSHPublicKey
+	}
+
+	if l := len(key.Key); l != PublicKeySize {
+		return nil, "", fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	var comment string
+	if len(fields) == 3 {
+		comment = string(bytes.TrimSpace(fields[2]))
+	}
+
+	return key.Key, comment, nil
+}
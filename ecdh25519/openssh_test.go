@@ -0,0 +1,93 @@
+package ecdh25519_test
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestMarshalOpenSSHPublicKey(t *testing.T) {
+	publicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, comment := range []string{"", "alice@example.com"} {
+		line, err := ecdh25519.MarshalOpenSSHPublicKey(publicKey, comment)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, gotComment, err := ecdh25519.ParseOpenSSHPublicKey(line)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(got, publicKey) || gotComment != comment {
+			t.Errorf("ParseOpenSSHPublicKey() = %v, %q, want %v, %q", got, gotComment, publicKey, comment)
+		}
+	}
+
+	if _, err := ecdh25519.MarshalOpenSSHPublicKey(publicKey[:16], ""); !errors.Is(err, ecdh25519.ErrBadPublicKeyLength) {
+		t.Errorf("MarshalOpenSSHPublicKey() error = %v, want %v", err, ecdh25519.ErrBadPublicKeyLength)
+	}
+}
+
+func TestParseOpenSSHPublicKey(t *testing.T) {
+	wire := func(keyType string, key []byte) string {
+		return base64.StdEncoding.EncodeToString(ssh.Marshal(struct {
+			Type string
+			Key  []byte
+		}{keyType, key}))
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr error
+	}{
+		{
+			name: "valid",
+			data: "ssh-x25519 " + wire("ssh-x25519", make([]byte, 32)) + " comment\n",
+		},
+		{
+			name:    "with short key",
+			data:    "ssh-x25519 " + wire("ssh-x25519", make([]byte, 16)),
+			wantErr: ecdh25519.ErrBadPublicKeyLength,
+		},
+		{
+			name:    "with mismatched wire type",
+			data:    "ssh-x25519 " + wire("ssh-ed25519", make([]byte, 32)),
+			wantErr: ecdh25519.ErrMalformedOpenSSHPublicKey,
+		},
+		{
+			name:    "with other key type",
+			data:    "ssh-ed25519 " + wire("ssh-ed25519", make([]byte, 32)),
+			wantErr: ecdh25519.ErrMalformedOpenSSHPublicKey,
+		},
+		{
+			name:    "with bad base64",
+			data:    "ssh-x25519 !!!",
+			wantErr: ecdh25519.ErrMalformedOpenSSHPublicKey,
+		},
+		{
+			name:    "with missing key",
+			data:    "ssh-x25519",
+			wantErr: ecdh25519.ErrMalformedOpenSSHPublicKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ecdh25519.ParseOpenSSHPublicKey([]byte(tt.data))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseOpenSSHPublicKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
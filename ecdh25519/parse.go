@@ -0,0 +1,56 @@
+package ecdh25519
+
+import "fmt"
+
+// Bytes returns a copy of the public key bytes, so that the caller cannot
+// modify the key through the result.
+func (p PublicKey) Bytes() []byte {
+	return append([]byte(nil), p...)
+}
+
+// Bytes returns a copy of the private key bytes, so that the caller cannot
+// modify the key through the result.
+func (p PrivateKey) Bytes() []byte {
+	return append([]byte(nil), p...)
+}
+
+// Clone returns an independent copy of the public key, or nil if p is nil.
+func (p PublicKey) Clone() PublicKey {
+	if p == nil {
+		return nil
+	}
+
+	return append(PublicKey{}, p...)
+}
+
+// Clone returns an independent copy of the private key, or nil if p is nil.
+// Zeroizing the copy does not affect p, and vice versa.
+func (p PrivateKey) Clone() PrivateKey {
+	if p == nil {
+		return nil
+	}
+
+	return append(PrivateKey{}, p...)
+}
+
+// ParsePublicKey returns a public key holding a copy of b, so that later
+// changes to b do not affect it. It returns ErrBadPublicKeyLength if b is not
+// PublicKeySize bytes long.
+func ParsePublicKey(b []byte) (PublicKey, error) {
+	if l := len(b); l != PublicKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	return append(PublicKey(nil), b...), nil
+}
+
+// ParsePrivateKey returns a private key holding a copy of b, so that later
+// changes to b do not affect it. It returns ErrBadPrivateKeyLength if b is not
+// PrivateKeySize bytes long. Unlike NewPrivateKey, it does not clamp the key.
+func ParsePrivateKey(b []byte) (PrivateKey, error) {
+	if l := len(b); l != PrivateKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	return append(PrivateKey(nil), b...), nil
+}
@@ -0,0 +1,79 @@
+package ecdh25519_test
+
+import (
+	"crypto/rand"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestParsePublicKey(t *testing.T) {
+	publicKey, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := publicKey.Bytes()
+	parsedPublicKey, err := ecdh25519.ParsePublicKey(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsedPrivateKey, err := ecdh25519.ParsePrivateKey(privateKey.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(parsedPublicKey, publicKey) || !reflect.DeepEqual(parsedPrivateKey, privateKey) {
+		t.Errorf("Parse round trip = %v, want %v", parsedPublicKey, publicKey)
+	}
+
+	b[0] ^= 1
+	if publicKey[0] == b[0] || parsedPublicKey[0] == b[0] {
+		t.Errorf("PublicKey.Bytes() or ParsePublicKey() aliases its input")
+	}
+
+	privateBytes := privateKey.Bytes()
+	privateBytes[0] ^= 1
+	if privateKey[0] == privateBytes[0] {
+		t.Errorf("PrivateKey.Bytes() aliases the key")
+	}
+
+	if _, err := ecdh25519.ParsePublicKey(b[:16]); !errors.Is(err, ecdh25519.ErrBadPublicKeyLength) {
+		t.Errorf("ParsePublicKey() error = %v, want %v", err, ecdh25519.ErrBadPublicKeyLength)
+	}
+
+	if _, err := ecdh25519.ParsePrivateKey(b[:16]); !errors.Is(err, ecdh25519.ErrBadPrivateKeyLength) {
+		t.Errorf("ParsePrivateKey() error = %v, want %v", err, ecdh25519.ErrBadPrivateKeyLength)
+	}
+}
+
+func TestPrivateKey_Clone(t *testing.T) {
+	publicKey, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clonedPublicKey := publicKey.Clone()
+	clonedPrivateKey := privateKey.Clone()
+	if !reflect.DeepEqual(clonedPublicKey, publicKey) || !reflect.DeepEqual(clonedPrivateKey, privateKey) {
+		t.Fatalf("Clone() = %v, want %v", clonedPublicKey, publicKey)
+	}
+
+	want := privateKey.Bytes()
+	clonedPrivateKey.Zeroize()
+	if !reflect.DeepEqual([]byte(privateKey), want) {
+		t.Errorf("PrivateKey.Clone() aliases the original key")
+	}
+
+	clonedPublicKey[0] ^= 1
+	if clonedPublicKey[0] == publicKey[0] {
+		t.Errorf("PublicKey.Clone() aliases the original key")
+	}
+
+	if ecdh25519.PublicKey(nil).Clone() != nil || ecdh25519.PrivateKey(nil).Clone() != nil {
+		t.Errorf("Clone() of a nil key is not nil")
+	}
+}
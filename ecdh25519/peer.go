@@ -0,0 +1,34 @@
+package ecdh25519
+
+import "fmt"
+
+// PrecomputedPeer is a peer public key prepared for repeated key agreement, as
+// when a server talks to a single gateway.
+//
+// The X25519 Montgomery ladder gains nothing from precomputation on the input
+// point, so a PrecomputedPeer only validates and copies the key once. The
+// speedup over GenerateSharedSecret is limited to skipping those steps.
+type PrecomputedPeer struct {
+	publicKey [PublicKeySize]byte
+}
+
+// NewPrecomputedPeer returns a PrecomputedPeer for publicKey.
+func NewPrecomputedPeer(publicKey PublicKey) (*PrecomputedPeer, error) {
+	if l := len(publicKey); l != PublicKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	p := &PrecomputedPeer{}
+	copy(p.publicKey[:], publicKey)
+
+	return p, nil
+}
+
+// SharedSecret generates the shared secret between privateKey and the peer.
+func (p *PrecomputedPeer) SharedSecret(privateKey PrivateKey) (SharedSecret, error) {
+	if l := len(privateKey); l != PrivateKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	return scalarMultChecked(privateKey, p.publicKey[:])
+}
@@ -0,0 +1,89 @@
+package ecdh25519_test
+
+import (
+	"crypto/rand"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestPrecomputedPeer_SharedSecret(t *testing.T) {
+	alicePublicKey, alicePrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bobPublicKey, bobPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	peer, err := ecdh25519.NewPrecomputedPeer(bobPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := peer.SharedSecret(alicePrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := ecdh25519.GenerateSharedSecret(bobPrivateKey, alicePublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PrecomputedPeer.SharedSecret() = %v, want %v", got, want)
+	}
+
+	if _, err := peer.SharedSecret(alicePrivateKey[:16]); !errors.Is(err, ecdh25519.ErrBadPrivateKeyLength) {
+		t.Errorf("PrecomputedPeer.SharedSecret() error = %v, want %v", err, ecdh25519.ErrBadPrivateKeyLength)
+	}
+
+	if _, err := ecdh25519.NewPrecomputedPeer(bobPublicKey[:16]); !errors.Is(err, ecdh25519.ErrBadPublicKeyLength) {
+		t.Errorf("NewPrecomputedPeer() error = %v, want %v", err, ecdh25519.ErrBadPublicKeyLength)
+	}
+}
+
+func BenchmarkFixedPeer(b *testing.B) {
+	peerPublicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	_, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("GenerateSharedSecret", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sharedSecret, err := ecdh25519.GenerateSharedSecret(privateKey, peerPublicKey)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			benchmarkSink ^= sharedSecret[0]
+		}
+	})
+
+	b.Run("PrecomputedPeer", func(b *testing.B) {
+		peer, err := ecdh25519.NewPrecomputedPeer(peerPublicKey)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			sharedSecret, err := peer.SharedSecret(privateKey)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			benchmarkSink ^= sharedSecret[0]
+		}
+	})
+}
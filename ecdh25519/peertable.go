@@ -0,0 +1,139 @@
+package ecdh25519
+
+import (
+	"fmt"
+	"sync"
+)
+
+// PeerTable caches the shared secrets between a local private key and a set of
+// peer public keys. It is safe for concurrent use.
+//
+// Secrets returned by a PeerTable are copies, so zeroizing the table's entries
+// on rotation or removal does not affect secrets already handed out.
+type PeerTable struct {
+	mu         sync.RWMutex
+	privateKey PrivateKey
+	secrets    map[[PublicKeySize]byte][]byte
+}
+
+// NewPeerTable returns an empty PeerTable for privateKey.
+func NewPeerTable(privateKey PrivateKey) (*PeerTable, error) {
+	if l := len(privateKey); l != PrivateKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	return &PeerTable{
+		privateKey: append(PrivateKey(nil), privateKey...),
+		secrets:    make(map[[PublicKeySize]byte][]byte),
+	}, nil
+}
+
+// Add computes and caches the shared secret with publicKey and returns it.
+func (t *PeerTable) Add(publicKey PublicKey) (SharedSecret, error) {
+	if l := len(publicKey); l != PublicKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	sharedSecret, err := GenerateSharedSecret(t.privateKey, publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var key [PublicKeySize]byte
+	copy(key[:], publicKey)
+
+	if old, ok := t.secrets[key]; ok {
+		zeroize(old)
+	}
+
+	t.secrets[key] = sharedSecret
+
+	return append([]byte(nil), sharedSecret...), nil
+}
+
+// SharedSecret returns the cached shared secret with publicKey, if any.
+func (t *PeerTable) SharedSecret(publicKey PublicKey) (SharedSecret, bool) {
+	if len(publicKey) != PublicKeySize {
+		return nil, false
+	}
+
+	var key [PublicKeySize]byte
+	copy(key[:], publicKey)
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	sharedSecret, ok := t.secrets[key]
+	if !ok {
+		return nil, false
+	}
+
+	return append([]byte(nil), sharedSecret...), true
+}
+
+// Remove zeroizes and removes the cached shared secret with publicKey. Keys
+// that are not PublicKeySize bytes long are ignored.
+func (t *PeerTable) Remove(publicKey PublicKey) {
+	if len(publicKey) != PublicKeySize {
+		return
+	}
+
+	var key [PublicKeySize]byte
+	copy(key[:], publicKey)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if sharedSecret, ok := t.secrets[key]; ok {
+		zeroize(sharedSecret)
+		delete(t.secrets, key)
+	}
+}
+
+// Len returns the number of peers in the table.
+func (t *PeerTable) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return len(t.secrets)
+}
+
+// RotateLocalKey replaces the local private key with newPrivateKey and
+// recomputes the shared secret with every peer. The old secrets and the old
+// private key are zeroized. If any secret cannot be recomputed, the table is
+// left unchanged.
+func (t *PeerTable) RotateLocalKey(newPrivateKey PrivateKey) error {
+	if l := len(newPrivateKey); l != PrivateKeySize {
+		return fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	secrets := make(map[[PublicKeySize]byte][]byte, len(t.secrets))
+	for key := range t.secrets {
+		sharedSecret, err := GenerateSharedSecret(newPrivateKey, key[:])
+		if err != nil {
+			for _, s := range secrets {
+				zeroize(s)
+			}
+
+			return err
+		}
+
+		secrets[key] = sharedSecret
+	}
+
+	for _, sharedSecret := range t.secrets {
+		zeroize(sharedSecret)
+	}
+
+	t.privateKey.Zeroize()
+	t.privateKey = append(PrivateKey(nil), newPrivateKey...)
+	t.secrets = secrets
+
+	return nil
+}
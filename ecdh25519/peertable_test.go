@@ -0,0 +1,134 @@
+package ecdh25519_test
+
+import (
+	"crypto/rand"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestPeerTable_RotateLocalKey(t *testing.T) {
+	_, oldPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table, err := ecdh25519.NewPeerTable(oldPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var peers []ecdh25519.PublicKey
+	for i := 0; i < 4; i++ {
+		publicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := table.Add(publicKey); err != nil {
+			t.Fatal(err)
+		}
+
+		peers = append(peers, publicKey)
+	}
+
+	oldSecret, ok := table.SharedSecret(peers[0])
+	if !ok {
+		t.Fatalf("PeerTable.SharedSecret() ok = false, want true")
+	}
+
+	_, newPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := table.RotateLocalKey(newPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	if table.Len() != len(peers) {
+		t.Errorf("PeerTable.Len() = %d, want %d", table.Len(), len(peers))
+	}
+
+	for _, publicKey := range peers {
+		got, ok := table.SharedSecret(publicKey)
+		if !ok {
+			t.Fatalf("PeerTable.SharedSecret() ok = false, want true")
+		}
+
+		want, err := ecdh25519.GenerateSharedSecret(newPrivateKey, publicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("PeerTable.SharedSecret() = %v, want %v", got, want)
+		}
+	}
+
+	if got, _ := table.SharedSecret(peers[0]); reflect.DeepEqual(got, oldSecret) {
+		t.Errorf("PeerTable.SharedSecret() did not change after rotation")
+	}
+
+	table.Remove(peers[0])
+	if _, ok := table.SharedSecret(peers[0]); ok {
+		t.Errorf("PeerTable.SharedSecret() ok = true after Remove(), want false")
+	}
+
+	// A truncated key must not match the entry of a key whose missing byte
+	// is zero.
+	zeroTail := append(ecdh25519.PublicKey(nil), peers[1][:31]...)
+	zeroTail = append(zeroTail, 0)
+	if _, err := table.Add(zeroTail); err != nil {
+		t.Fatal(err)
+	}
+
+	table.Remove(zeroTail[:31])
+	if _, ok := table.SharedSecret(zeroTail); !ok {
+		t.Errorf("PeerTable.Remove() with a short key removed another entry")
+	}
+}
+
+func TestPeerTable_concurrent(t *testing.T) {
+	_, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table, err := ecdh25519.NewPeerTable(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			publicKey, newPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if _, err := table.Add(publicKey); err != nil {
+				t.Error(err)
+			}
+
+			table.SharedSecret(publicKey)
+
+			if err := table.RotateLocalKey(newPrivateKey); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if table.Len() != 8 {
+		t.Errorf("PeerTable.Len() = %d, want 8", table.Len())
+	}
+}
@@ -0,0 +1,108 @@
+package ecdh25519
+
+import (
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+const (
+	// PublicKeyPEMType is the PEM block type of ecdh25519 public keys.
+	PublicKeyPEMType = "X25519 PUBLIC KEY"
+	// PrivateKeyPEMType is the PEM block type of ecdh25519 private keys.
+	PrivateKeyPEMType = "X25519 PRIVATE KEY"
+)
+
+var ErrMalformedPEM = errors.New("ecdh25519: malformed pem")
+
+func init() {
+	registerCapability("pem")
+}
+
+// MarshalPEM encodes the public key as a PublicKeyPEMType PEM block.
+func (p PublicKey) MarshalPEM() ([]byte, error) {
+	if l := len(p); l != PublicKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: PublicKeyPEMType, Bytes: p}), nil
+}
+
+// MarshalPEM encodes the private key as a PrivateKeyPEMType PEM block. It
+// refuses to encode a key of the wrong length rather than write a corrupt file.
+func (p PrivateKey) MarshalPEM() ([]byte, error) {
+	if l := len(p); l != PrivateKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: PrivateKeyPEMType, Bytes: p}), nil
+}
+
+// ParsePublicKeyPEM parses the first PEM block in data, which must be of type
+// PublicKeyPEMType.
+func ParsePublicKeyPEM(data []byte) (PublicKey, error) {
+	b, err := decodePEM(data, PublicKeyPEMType)
+	if err != nil {
+		return nil, err
+	}
+
+	if l := len(b); l != PublicKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	return PublicKey(b), nil
+}
+
+// ParsePrivateKeyPEM parses the first PEM block in data, which must be of type
+// PrivateKeyPEMType.
+func ParsePrivateKeyPEM(data []byte) (PrivateKey, error) {
+	b, err := decodePEM(data, PrivateKeyPEMType)
+	if err != nil {
+		return nil, err
+	}
+
+	if l := len(b); l != PrivateKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	return PrivateKey(b), nil
+}
+
+func decodePEM(data []byte, blockType string) ([]byte, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: no pem block", ErrMalformedPEM)
+	}
+
+	if block.Type != blockType {
+		return nil, fmt.Errorf("%w: unexpected block type %q", ErrMalformedPEM, block.Type)
+	}
+
+	return block.Bytes, nil
+}
+
+// ParsePublicKeysPEM parses every PublicKeyPEMType block in data, such as a
+// trust bundle holding multiple keys. Blocks of other types are skipped; an
+// error is returned only if a matching block is malformed.
+func ParsePublicKeysPEM(data []byte) ([]PublicKey, error) {
+	var publicKeys []PublicKey
+	for i := 0; ; i++ {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		if block.Type != PublicKeyPEMType {
+			continue
+		}
+
+		if l := len(block.Bytes); l != PublicKeySize {
+			return nil, fmt.Errorf("%w: pem block %d: %d", ErrBadPublicKeyLength, i, l)
+		}
+
+		publicKeys = append(publicKeys, PublicKey(block.Bytes))
+	}
+
+	return publicKeys, nil
+}
@@ -0,0 +1,153 @@
+package ecdh25519_test
+
+import (
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestParsePublicKeysPEM(t *testing.T) {
+	alicePublicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bobPublicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var bundle []byte
+	bundle = append(bundle, pem.EncodeToMemory(&pem.Block{Type: ecdh25519.PublicKeyPEMType, Bytes: alicePublicKey})...)
+	bundle = append(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte{1, 2, 3}})...)
+	bundle = append(bundle, pem.EncodeToMemory(&pem.Block{Type: ecdh25519.PublicKeyPEMType, Bytes: bobPublicKey})...)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    []ecdh25519.PublicKey
+		wantErr error
+	}{
+		{
+			name: "with mixed bundle",
+			data: bundle,
+			want: []ecdh25519.PublicKey{alicePublicKey, bobPublicKey},
+		},
+		{
+			name: "with no blocks",
+			data: []byte("not pem"),
+		},
+		{
+			name:    "with malformed key",
+			data:    append(bundle, pem.EncodeToMemory(&pem.Block{Type: ecdh25519.PublicKeyPEMType, Bytes: bobPublicKey[:16]})...),
+			wantErr: ecdh25519.ErrBadPublicKeyLength,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ecdh25519.ParsePublicKeysPEM(tt.data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParsePublicKeysPEM() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePublicKeysPEM() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublicKey_MarshalPEM(t *testing.T) {
+	publicKey, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicKeyPEM, err := publicKey.MarshalPEM()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gotPublicKey, err := ecdh25519.ParsePublicKeyPEM(publicKeyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(gotPublicKey, publicKey) {
+		t.Errorf("ParsePublicKeyPEM() = %v, want %v", gotPublicKey, publicKey)
+	}
+
+	privateKeyPEM, err := privateKey.MarshalPEM()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gotPrivateKey, err := ecdh25519.ParsePrivateKeyPEM(privateKeyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(gotPrivateKey, privateKey) {
+		t.Errorf("ParsePrivateKeyPEM() = %v, want %v", gotPrivateKey, privateKey)
+	}
+
+	if _, err := publicKey[:16].MarshalPEM(); !errors.Is(err, ecdh25519.ErrBadPublicKeyLength) {
+		t.Errorf("PublicKey.MarshalPEM() error = %v, want %v", err, ecdh25519.ErrBadPublicKeyLength)
+	}
+
+	if _, err := privateKey[:16].MarshalPEM(); !errors.Is(err, ecdh25519.ErrBadPrivateKeyLength) {
+		t.Errorf("PrivateKey.MarshalPEM() error = %v, want %v", err, ecdh25519.ErrBadPrivateKeyLength)
+	}
+}
+
+func TestParsePrivateKeyPEM(t *testing.T) {
+	publicKey, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicKeyPEM, err := publicKey.MarshalPEM()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{
+			name:    "with public key block",
+			data:    publicKeyPEM,
+			wantErr: ecdh25519.ErrMalformedPEM,
+		},
+		{
+			name:    "with no block",
+			data:    []byte("not pem"),
+			wantErr: ecdh25519.ErrMalformedPEM,
+		},
+		{
+			name:    "with short key",
+			data:    pem.EncodeToMemory(&pem.Block{Type: ecdh25519.PrivateKeyPEMType, Bytes: privateKey[:16]}),
+			wantErr: ecdh25519.ErrBadPrivateKeyLength,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ecdh25519.ParsePrivateKeyPEM(tt.data); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParsePrivateKeyPEM() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := ecdh25519.ParsePublicKeyPEM(pem.EncodeToMemory(&pem.Block{Type: ecdh25519.PublicKeyPEMType, Bytes: publicKey[:16]})); !errors.Is(err, ecdh25519.ErrBadPublicKeyLength) {
+		t.Errorf("ParsePublicKeyPEM() error = %v, want %v", err, ecdh25519.ErrBadPublicKeyLength)
+	}
+}
@@ -0,0 +1,27 @@
+package ecdh25519
+
+import "fmt"
+
+// PublicKeyFromProtoBytes validates the contents of a Protobuf bytes field and
+// returns it as a PublicKey. The returned key does not alias b.
+func PublicKeyFromProtoBytes(b []byte) (PublicKey, error) {
+	if l := len(b); l != PublicKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	publicKey := make(PublicKey, PublicKeySize)
+	copy(publicKey, b)
+
+	return publicKey, nil
+}
+
+// ProtoBytes returns a copy of the public key for use as a Protobuf bytes
+// field. It returns nil, an empty field, if p is not PublicKeySize bytes long,
+// which PublicKeyFromProtoBytes rejects on the receiving side.
+func (p PublicKey) ProtoBytes() []byte {
+	if len(p) != PublicKeySize {
+		return nil
+	}
+
+	return append([]byte(nil), p...)
+}
@@ -0,0 +1,82 @@
+package ecdh25519_test
+
+import (
+	"crypto/rand"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestPublicKeyFromProtoBytes(t *testing.T) {
+	publicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		b       []byte
+		want    ecdh25519.PublicKey
+		wantErr error
+	}{
+		{
+			name: "with valid key",
+			b:    publicKey.ProtoBytes(),
+			want: publicKey,
+		},
+		{
+			name:    "with empty field",
+			b:       nil,
+			wantErr: ecdh25519.ErrBadPublicKeyLength,
+		},
+		{
+			name:    "with short key",
+			b:       publicKey[:31],
+			wantErr: ecdh25519.ErrBadPublicKeyLength,
+		},
+		{
+			name:    "with long key",
+			b:       append(publicKey.ProtoBytes(), 0),
+			wantErr: ecdh25519.ErrBadPublicKeyLength,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ecdh25519.PublicKeyFromProtoBytes(tt.b)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PublicKeyFromProtoBytes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PublicKeyFromProtoBytes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublicKey_ProtoBytes(t *testing.T) {
+	publicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := publicKey.ProtoBytes()
+	if !reflect.DeepEqual(b, []byte(publicKey)) {
+		t.Fatalf("PublicKey.ProtoBytes() = %v, want %v", b, publicKey)
+	}
+
+	b[0] ^= 1
+	if b[0] == publicKey[0] {
+		t.Errorf("PublicKey.ProtoBytes() aliases the key")
+	}
+
+	for _, malformed := range []ecdh25519.PublicKey{nil, publicKey[:16]} {
+		if got := malformed.ProtoBytes(); got != nil {
+			t.Errorf("PublicKey.ProtoBytes() with %d-byte key = %v, want nil", len(malformed), got)
+		}
+	}
+}
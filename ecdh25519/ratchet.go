@@ -0,0 +1,61 @@
+package ecdh25519
+
+var (
+	ratchetInitInfo       = []byte("ecdh25519 ratchet init")
+	ratchetChainKeyInfo   = []byte("ecdh25519 ratchet chain key")
+	ratchetMessageKeyInfo = []byte("ecdh25519 ratchet message key")
+)
+
+// RatchetKeySize is the size, in bytes, of the keys returned by Ratchet.NextKey.
+const RatchetKeySize = 32
+
+// Ratchet is a symmetric key ratchet seeded from a shared secret. Each call to
+// NextKey derives a message key and a new chain key from the current chain key
+// with HKDF-SHA256, then zeroizes the old chain key, so a compromise of the
+// ratchet state does not reveal earlier message keys.
+//
+// The derivation is deterministic: two ratchets seeded with the same secret
+// return the same sequence of keys, so both sides of a channel stay in sync as
+// long as they advance once per message. A Ratchet is not safe for concurrent
+// use.
+type Ratchet struct {
+	chainKey [32]byte
+}
+
+// NewRatchet returns a Ratchet seeded from sharedSecret, which is not retained.
+// It returns ErrEmptySharedSecret if sharedSecret is empty.
+func NewRatchet(sharedSecret []byte) (*Ratchet, error) {
+	if len(sharedSecret) == 0 {
+		return nil, ErrEmptySharedSecret
+	}
+
+	r := &Ratchet{}
+	if err := hkdfExpand(r.chainKey[:], sharedSecret, nil, ratchetInitInfo); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// NextKey advances the ratchet and returns the next RatchetKeySize-byte message
+// key.
+func (r *Ratchet) NextKey() []byte {
+	messageKey := make([]byte, RatchetKeySize)
+	var chainKey [32]byte
+
+	// HKDF cannot fail for outputs this short.
+	_ = hkdfExpand(messageKey, r.chainKey[:], nil, ratchetMessageKeyInfo)
+	_ = hkdfExpand(chainKey[:], r.chainKey[:], nil, ratchetChainKeyInfo)
+
+	zeroize(r.chainKey[:])
+	r.chainKey = chainKey
+	zeroize(chainKey[:])
+
+	return messageKey
+}
+
+// Zeroize overwrites the chain key with zeros. The ratchet must not be used
+// afterwards.
+func (r *Ratchet) Zeroize() {
+	zeroize(r.chainKey[:])
+}
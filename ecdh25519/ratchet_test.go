@@ -0,0 +1,55 @@
+package ecdh25519_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestRatchet_NextKey(t *testing.T) {
+	secret := []byte("shared secret")
+
+	alice, err := ecdh25519.NewRatchet(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bob, err := ecdh25519.NewRatchet(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"870a8ef788bfc7b5705e873a2f5013a29934f003664d1634a4cb0125d218ac8c",
+		"8540d8a8f7bf2322a3cf281b77fda0f842e4529dec63e89f2668823086fb0a2f",
+	}
+
+	for i, w := range want {
+		aliceKey := alice.NextKey()
+		bobKey := bob.NextKey()
+
+		if got := hex.EncodeToString(aliceKey); got != w {
+			t.Errorf("Ratchet.NextKey() step %d = %v, want %v", i, got, w)
+		}
+
+		if !reflect.DeepEqual(aliceKey, bobKey) {
+			t.Errorf("Ratchet.NextKey() step %d = %x and %x for the same secret", i, aliceKey, bobKey)
+		}
+	}
+
+	other, err := ecdh25519.NewRatchet([]byte("other secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := hex.EncodeToString(other.NextKey()); got == want[0] {
+		t.Errorf("Ratchet.NextKey() returned the same key for different secrets")
+	}
+
+	if _, err := ecdh25519.NewRatchet(nil); !errors.Is(err, ecdh25519.ErrEmptySharedSecret) {
+		t.Errorf("NewRatchet() error = %v, want %v", err, ecdh25519.ErrEmptySharedSecret)
+	}
+}
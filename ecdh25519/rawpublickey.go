@@ -0,0 +1,72 @@
+package ecdh25519
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+)
+
+var ErrMalformedRawPublicKey = errors.New("ecdh25519: malformed raw public key")
+
+// oidX25519 is the X25519 algorithm identifier from RFC 8410, section 3.
+var oidX25519 = asn1.ObjectIdentifier{1, 3, 101, 110}
+
+func init() {
+	registerCapability("spki")
+}
+
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// MarshalRawPublicKey encodes publicKey as the DER SubjectPublicKeyInfo
+// structure defined in RFC 8410, which is the form RFC 7250 raw public keys
+// carry in place of a certificate. The output is the same as the PKIX
+// encoding of an X25519 public key.
+func MarshalRawPublicKey(publicKey PublicKey) ([]byte, error) {
+	if l := len(publicKey); l != PublicKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	return asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: oidX25519},
+		PublicKey: asn1.BitString{Bytes: publicKey, BitLength: PublicKeySize * 8},
+	})
+}
+
+// ParseRawPublicKey parses an RFC 7250 raw public key, as produced by
+// MarshalRawPublicKey.
+func ParseRawPublicKey(der []byte) (PublicKey, error) {
+	var spki subjectPublicKeyInfo
+	rest, err := asn1.Unmarshal(der, &spki)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedRawPublicKey, err)
+	}
+
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("%w: trailing data", ErrMalformedRawPublicKey)
+	}
+
+	if !spki.Algorithm.Algorithm.Equal(oidX25519) {
+		return nil, fmt.Errorf("%w: unexpected algorithm %v", ErrMalformedRawPublicKey, spki.Algorithm.Algorithm)
+	}
+
+	if len(spki.Algorithm.Parameters.FullBytes) != 0 {
+		return nil, fmt.Errorf("%w: unexpected algorithm parameters", ErrMalformedRawPublicKey)
+	}
+
+	if spki.PublicKey.BitLength%8 != 0 {
+		return nil, fmt.Errorf("%w: bad bit string length", ErrMalformedRawPublicKey)
+	}
+
+	if l := len(spki.PublicKey.Bytes); l != PublicKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	publicKey := make(PublicKey, PublicKeySize)
+	copy(publicKey, spki.PublicKey.Bytes)
+
+	return publicKey, nil
+}
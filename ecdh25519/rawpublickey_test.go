@@ -0,0 +1,89 @@
+package ecdh25519_test
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+// example from: https://www.rfc-editor.org/rfc/rfc8410#section-10.1
+
+func TestMarshalRawPublicKey(t *testing.T) {
+	publicKey, err := hex.DecodeString("19bf44096984cdfe8541bac167dc3b96c85086aa30b6b6cb0c5c38ad703166e1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := base64.StdEncoding.DecodeString("MCowBQYDK2VuAyEAGb9ECWmEzf6FQbrBZ9w7lshQhqowtrbLDFw4rXAxZuE=")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ecdh25519.MarshalRawPublicKey(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MarshalRawPublicKey() = %x, want %x", got, want)
+	}
+
+	parsed, err := ecdh25519.ParseRawPublicKey(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(parsed, ecdh25519.PublicKey(publicKey)) {
+		t.Errorf("ParseRawPublicKey() = %x, want %x", parsed, publicKey)
+	}
+
+	if _, err := ecdh25519.MarshalRawPublicKey(publicKey[:16]); !errors.Is(err, ecdh25519.ErrBadPublicKeyLength) {
+		t.Errorf("MarshalRawPublicKey() error = %v, want %v", err, ecdh25519.ErrBadPublicKeyLength)
+	}
+}
+
+func TestParseRawPublicKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		der     string
+		wantErr error
+	}{
+		{
+			name:    "with ed25519 algorithm",
+			der:     "302a300506032b6570032100" + "19bf44096984cdfe8541bac167dc3b96c85086aa30b6b6cb0c5c38ad703166e1",
+			wantErr: ecdh25519.ErrMalformedRawPublicKey,
+		},
+		{
+			name:    "with short key",
+			der:     "301a300506032b656e031100" + "19bf44096984cdfe8541bac167dc3b96",
+			wantErr: ecdh25519.ErrBadPublicKeyLength,
+		},
+		{
+			name:    "with trailing data",
+			der:     "302a300506032b656e032100" + "19bf44096984cdfe8541bac167dc3b96c85086aa30b6b6cb0c5c38ad703166e1" + "00",
+			wantErr: ecdh25519.ErrMalformedRawPublicKey,
+		},
+		{
+			name:    "with garbage",
+			der:     "0102",
+			wantErr: ecdh25519.ErrMalformedRawPublicKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			der, err := hex.DecodeString(tt.der)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := ecdh25519.ParseRawPublicKey(der); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseRawPublicKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
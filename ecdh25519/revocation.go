@@ -0,0 +1,64 @@
+package ecdh25519
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+var ErrRevokedKey = errors.New("ecdh25519: revoked public key")
+
+// RevocationChecker reports whether a peer public key has been revoked. It is
+// implemented by the caller, backed by a revocation list, a database or any
+// other source. The checker is optional: the Checked functions skip the check
+// when it is nil and behave like their unchecked counterparts.
+type RevocationChecker interface {
+	IsRevoked(publicKey PublicKey) (bool, error)
+}
+
+// GenerateSharedSecretChecked is like GenerateSharedSecret, but it first consults
+// checker and returns ErrRevokedKey if publicKey has been revoked.
+func GenerateSharedSecretChecked(privateKey PrivateKey, publicKey PublicKey, checker RevocationChecker) (SharedSecret, error) {
+	if err := checkRevocation(checker, publicKey); err != nil {
+		return nil, err
+	}
+
+	return GenerateSharedSecret(privateKey, publicKey)
+}
+
+// ClientHandshakeChecked is like ClientHandshake, but it first consults checker
+// and returns ErrRevokedKey if serverStatic has been revoked.
+func ClientHandshakeChecked(rand io.Reader, serverStatic PublicKey, context []byte, checker RevocationChecker) (PublicKey, []byte, error) {
+	if err := checkRevocation(checker, serverStatic); err != nil {
+		return nil, nil, err
+	}
+
+	return ClientHandshake(rand, serverStatic, context)
+}
+
+// SealHandshakeChecked is like SealHandshake, but it first consults checker and
+// returns ErrRevokedKey if peerStatic has been revoked.
+func SealHandshakeChecked(ephemeralPrivateKey PrivateKey, peerStatic PublicKey, plaintext, aad []byte, checker RevocationChecker) ([]byte, error) {
+	if err := checkRevocation(checker, peerStatic); err != nil {
+		return nil, err
+	}
+
+	return SealHandshake(ephemeralPrivateKey, peerStatic, plaintext, aad)
+}
+
+func checkRevocation(checker RevocationChecker, publicKey PublicKey) error {
+	if checker == nil {
+		return nil
+	}
+
+	revoked, err := checker.IsRevoked(publicKey)
+	if err != nil {
+		return fmt.Errorf("ecdh25519: revocation check: %w", err)
+	}
+
+	if revoked {
+		return ErrRevokedKey
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package ecdh25519_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+type stubChecker struct {
+	revoked ecdh25519.PublicKey
+	err     error
+	calls   int
+}
+
+func (c *stubChecker) IsRevoked(publicKey ecdh25519.PublicKey) (bool, error) {
+	c.calls++
+	return bytes.Equal(publicKey, c.revoked), c.err
+}
+
+func TestGenerateSharedSecretChecked(t *testing.T) {
+	_, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revokedPublicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	goodPublicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errBackend := errors.New("backend unavailable")
+
+	tests := []struct {
+		name      string
+		publicKey ecdh25519.PublicKey
+		checker   *stubChecker
+		wantErr   error
+	}{
+		{
+			name:      "with good key",
+			publicKey: goodPublicKey,
+			checker:   &stubChecker{revoked: revokedPublicKey},
+		},
+		{
+			name:      "with revoked key",
+			publicKey: revokedPublicKey,
+			checker:   &stubChecker{revoked: revokedPublicKey},
+			wantErr:   ecdh25519.ErrRevokedKey,
+		},
+		{
+			name:      "with failing checker",
+			publicKey: goodPublicKey,
+			checker:   &stubChecker{err: errBackend},
+			wantErr:   errBackend,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ecdh25519.GenerateSharedSecretChecked(privateKey, tt.publicKey, tt.checker)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GenerateSharedSecretChecked() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.checker.calls != 1 {
+				t.Errorf("IsRevoked() called %d times, want 1", tt.checker.calls)
+			}
+		})
+	}
+}
+
+func TestClientHandshakeChecked(t *testing.T) {
+	serverPublicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checker := &stubChecker{revoked: serverPublicKey}
+
+	if _, _, err := ecdh25519.ClientHandshakeChecked(rand.Reader, serverPublicKey, nil, checker); !errors.Is(err, ecdh25519.ErrRevokedKey) {
+		t.Errorf("ClientHandshakeChecked() error = %v, want %v", err, ecdh25519.ErrRevokedKey)
+	}
+
+	_, ephemeralPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ecdh25519.SealHandshakeChecked(ephemeralPrivateKey, serverPublicKey, nil, nil, checker); !errors.Is(err, ecdh25519.ErrRevokedKey) {
+		t.Errorf("SealHandshakeChecked() error = %v, want %v", err, ecdh25519.ErrRevokedKey)
+	}
+}
+
+func TestChecked_nilChecker(t *testing.T) {
+	publicKey, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ecdh25519.GenerateSharedSecretChecked(privateKey, publicKey, nil)
+	if err != nil {
+		t.Fatalf("GenerateSharedSecretChecked() with nil checker error = %v, want nil", err)
+	}
+
+	want, err := ecdh25519.GenerateSharedSecret(privateKey, publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("GenerateSharedSecretChecked() with nil checker = %x, want %x", got, want)
+	}
+
+	if _, _, err := ecdh25519.ClientHandshakeChecked(rand.Reader, publicKey, nil, nil); err != nil {
+		t.Errorf("ClientHandshakeChecked() with nil checker error = %v, want nil", err)
+	}
+
+	if _, err := ecdh25519.SealHandshakeChecked(privateKey, publicKey, []byte("hello"), nil, nil); err != nil {
+		t.Errorf("SealHandshakeChecked() with nil checker error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,25 @@
+package ecdh25519
+
+import (
+	"fmt"
+	"io"
+)
+
+// RotateKeyPair generates a new key pair using entropy from rand, like
+// GenerateKeyPair, and zeroizes oldPrivateKey, so that rotating a static key
+// cannot leave the old material in memory by mistake. If generating the new
+// pair fails, oldPrivateKey is left untouched so the caller can keep using it.
+func RotateKeyPair(oldPrivateKey PrivateKey, rand io.Reader) (PublicKey, PrivateKey, error) {
+	if l := len(oldPrivateKey); l != PrivateKeySize {
+		return nil, nil, fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	publicKey, privateKey, err := GenerateKeyPair(rand)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	oldPrivateKey.Zeroize()
+
+	return publicKey, privateKey, nil
+}
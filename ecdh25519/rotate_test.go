@@ -0,0 +1,50 @@
+package ecdh25519_test
+
+import (
+	"crypto/rand"
+	"errors"
+	"reflect"
+	"testing"
+	"testing/iotest"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestRotateKeyPair(t *testing.T) {
+	oldPublicKey, oldPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := oldPrivateKey.Clone()
+
+	errRead := errors.New("read failed")
+	if _, _, err := ecdh25519.RotateKeyPair(oldPrivateKey, iotest.ErrReader(errRead)); !errors.Is(err, errRead) {
+		t.Errorf("RotateKeyPair() error = %v, want %v", err, errRead)
+	}
+
+	if !reflect.DeepEqual(oldPrivateKey, want) {
+		t.Errorf("RotateKeyPair() modified the old private key on failure")
+	}
+
+	publicKey, privateKey, err := ecdh25519.RotateKeyPair(oldPrivateKey, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(oldPrivateKey, make(ecdh25519.PrivateKey, ecdh25519.PrivateKeySize)) {
+		t.Errorf("RotateKeyPair() old private key = %x, want zeros", []byte(oldPrivateKey))
+	}
+
+	if publicKey.Equal(oldPublicKey) || privateKey.Equal(want) {
+		t.Errorf("RotateKeyPair() returned the old key pair")
+	}
+
+	if ok, err := privateKey.MatchesPublicKey(publicKey); err != nil || !ok {
+		t.Errorf("RotateKeyPair() returned a mismatched key pair")
+	}
+
+	if _, _, err := ecdh25519.RotateKeyPair(nil, rand.Reader); !errors.Is(err, ecdh25519.ErrBadPrivateKeyLength) {
+		t.Errorf("RotateKeyPair() error = %v, want %v", err, ecdh25519.ErrBadPrivateKeyLength)
+	}
+}
@@ -0,0 +1,57 @@
+package ecdh25519
+
+import "errors"
+
+var ErrBadEmojiCount = errors.New("ecdh25519: bad emoji count")
+
+var emojiSASInfo = []byte("ecdh25519 emoji sas")
+
+// maxEmojiCount is the longest SAS that fits in the output of HKDF-SHA256.
+const maxEmojiCount = MaxDerivedKeySize * 8 / 6
+
+// sasEmoji is the SAS emoji table from the Matrix specification, indexed by
+// 6-bit value.
+var sasEmoji = [64]string{
+	"🐶", "🐱", "🦁", "🐎", "🦄", "🐷", "🐘", "🐰", // dog, cat, lion, horse, unicorn, pig, elephant, rabbit
+	"🐼", "🐓", "🐧", "🐢", "🐟", "🐙", "🦋", "🌷", // panda, rooster, penguin, turtle, fish, octopus, butterfly, flower
+	"🌳", "🌵", "🍄", "🌏", "🌙", "☁️", "🔥", "🍌", // tree, cactus, mushroom, globe, moon, cloud, fire, banana
+	"🍎", "🍓", "🌽", "🍕", "🎂", "❤️", "😀", "🤖", // apple, strawberry, corn, pizza, cake, heart, smiley, robot
+	"🎩", "👓", "🔧", "🎅", "👍", "☂️", "⌛", "⏰", // hat, glasses, spanner, santa, thumbs up, umbrella, hourglass, clock
+	"🎁", "💡", "📕", "✏️", "📎", "✂️", "🔒", "🔑", // gift, light bulb, book, pencil, paperclip, scissors, lock, key
+	"🔨", "☎️", "🏁", "🚂", "🚲", "✈️", "🚀", "🏆", // hammer, telephone, flag, train, bicycle, aeroplane, rocket, trophy
+	"⚽", "🎸", "🎺", "🔔", "⚓", "🎧", "📁", "📌", // ball, guitar, trumpet, bell, anchor, headphones, folder, pin
+}
+
+func init() {
+	registerCapability("emoji-sas")
+}
+
+// EmojiSAS derives a short authentication string of count emoji from a shared
+// secret, for comparison by users over an out-of-band channel. Both sides of an
+// exchange derive the same sequence from the same secret.
+//
+// The secret is expanded with HKDF-SHA256 and every 6 bits of output select an
+// entry of the Matrix SAS emoji table. It returns ErrBadEmojiCount if count is
+// less than 1 or more than that output can provide.
+func EmojiSAS(secret []byte, count int) ([]string, error) {
+	if count < 1 || count > maxEmojiCount {
+		return nil, ErrBadEmojiCount
+	}
+
+	buf := make([]byte, (count*6+7)/8)
+	if err := hkdfExpand(buf, secret, nil, emojiSASInfo); err != nil {
+		return nil, err
+	}
+
+	emoji := make([]string, count)
+	for i := range emoji {
+		var index int
+		for bit := i * 6; bit < i*6+6; bit++ {
+			index = index<<1 | int(buf[bit/8]>>(7-bit%8)&1)
+		}
+
+		emoji[i] = sasEmoji[index]
+	}
+
+	return emoji, nil
+}
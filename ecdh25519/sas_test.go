@@ -0,0 +1,51 @@
+package ecdh25519_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestEmojiSAS(t *testing.T) {
+	secret := []byte("shared secret")
+
+	got, err := ecdh25519.EmojiSAS(secret, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 7 {
+		t.Fatalf("EmojiSAS() returned %d emoji, want 7", len(got))
+	}
+
+	again, err := ecdh25519.EmojiSAS(secret, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, again) {
+		t.Errorf("EmojiSAS() = %v, then %v", got, again)
+	}
+
+	other, err := ecdh25519.EmojiSAS([]byte("other secret"), 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reflect.DeepEqual(got, other) {
+		t.Errorf("EmojiSAS() returned the same sequence for different secrets")
+	}
+
+	if _, err := ecdh25519.EmojiSAS(secret, ecdh25519.MaxDerivedKeySize*8/6); err != nil {
+		t.Errorf("EmojiSAS() with maximum count error = %v, want nil", err)
+	}
+
+	for _, count := range []int{0, -1, ecdh25519.MaxDerivedKeySize*8/6 + 1, math.MaxInt / 4} {
+		if _, err := ecdh25519.EmojiSAS(secret, count); !errors.Is(err, ecdh25519.ErrBadEmojiCount) {
+			t.Errorf("EmojiSAS() with count %d error = %v, want %v", count, err, ecdh25519.ErrBadEmojiCount)
+		}
+	}
+}
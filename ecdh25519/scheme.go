@@ -0,0 +1,28 @@
+package ecdh25519
+
+import (
+	"io"
+
+	"github.com/adnsio/ecdh"
+)
+
+// Scheme is the ecdh.Scheme implemented by this package.
+var Scheme ecdh.Scheme = scheme{}
+
+func init() {
+	ecdh.Register(Scheme)
+}
+
+type scheme struct{}
+
+func (scheme) Name() string {
+	return "x25519"
+}
+
+func (scheme) GenerateKeyPair(rand io.Reader) ([]byte, []byte, error) {
+	return GenerateKeyPair(rand)
+}
+
+func (scheme) SharedSecret(privateKey, publicKey []byte) ([]byte, error) {
+	return GenerateSharedSecret(privateKey, publicKey)
+}
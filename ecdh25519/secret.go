@@ -0,0 +1,18 @@
+package ecdh25519
+
+// SharedSecret is the type of shared secrets returned by GenerateSharedSecret.
+// It has []byte as its underlying type, so it can be passed to any function
+// that takes a []byte, such as DeriveKey, without a conversion.
+type SharedSecret []byte
+
+// Bytes returns a copy of the shared secret as a plain byte slice, like
+// PublicKey.Bytes and PrivateKey.Bytes. Zeroize does not clear the copy.
+func (s SharedSecret) Bytes() []byte {
+	return append([]byte(nil), s...)
+}
+
+// Zeroize overwrites the shared secret with zeros, once the keys derived from
+// it are in place.
+func (s SharedSecret) Zeroize() {
+	zeroize(s)
+}
@@ -0,0 +1,43 @@
+package ecdh25519_test
+
+import (
+	"crypto/rand"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestSharedSecret_Zeroize(t *testing.T) {
+	_, alicePrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bobPublicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sharedSecret, err := ecdh25519.GenerateSharedSecret(alicePrivateKey, bobPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A SharedSecret is accepted wherever a []byte is expected.
+	if _, err := ecdh25519.DeriveKey(sharedSecret, nil, nil, 32); err != nil {
+		t.Fatal(err)
+	}
+
+	b := sharedSecret.Bytes()
+	sharedSecret.Zeroize()
+
+	want := make([]byte, ecdh25519.SharedSecretSize)
+	if !reflect.DeepEqual([]byte(sharedSecret), want) {
+		t.Errorf("SharedSecret.Zeroize() left %v, want %v", []byte(sharedSecret), want)
+	}
+
+	if reflect.DeepEqual(b, want) {
+		t.Errorf("SharedSecret.Bytes() aliases the secret")
+	}
+}
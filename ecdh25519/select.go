@@ -0,0 +1,49 @@
+package ecdh25519
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+)
+
+var ErrBadKeyIndex = errors.New("ecdh25519: key index out of range")
+
+// SharedSecretSelect generates the shared secret between publicKey and
+// privateKeys[index] without revealing index through timing: the key agreement
+// is performed with every private key and the result is picked with
+// subtle.ConstantTimeCopy. It is meant for servers that hold several static
+// keys and must not leak which one a handshake uses.
+//
+// The running time grows with len(privateKeys), which is not secret. All keys
+// are checked before any secret is computed.
+func SharedSecretSelect(privateKeys []PrivateKey, publicKey PublicKey, index int) (SharedSecret, error) {
+	if index < 0 || index >= len(privateKeys) {
+		return nil, fmt.Errorf("%w: %d", ErrBadKeyIndex, index)
+	}
+
+	if l := len(publicKey); l != PublicKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	for i, privateKey := range privateKeys {
+		if l := len(privateKey); l != PrivateKeySize {
+			return nil, fmt.Errorf("%w: %d: index %d", ErrBadPrivateKeyLength, l, i)
+		}
+	}
+
+	var candidate [SharedSecretSize]byte
+	defer zeroize(candidate[:])
+
+	sharedSecret := make(SharedSecret, SharedSecretSize)
+	for i, privateKey := range privateKeys {
+		// A low-order point yields an all-zero output with every clamped
+		// scalar, so failing here does not depend on index.
+		if err := scalarMultInto(candidate[:], privateKey, publicKey); err != nil {
+			return nil, err
+		}
+
+		subtle.ConstantTimeCopy(subtle.ConstantTimeEq(int32(i), int32(index)), sharedSecret, candidate[:])
+	}
+
+	return sharedSecret, nil
+}
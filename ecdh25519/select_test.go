@@ -0,0 +1,95 @@
+package ecdh25519_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestSharedSecretSelect(t *testing.T) {
+	privateKeys := make([]ecdh25519.PrivateKey, 4)
+	for i := range privateKeys {
+		_, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		privateKeys[i] = privateKey
+	}
+
+	publicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, privateKey := range privateKeys {
+		got, err := ecdh25519.SharedSecretSelect(privateKeys, publicKey, i)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want, err := ecdh25519.GenerateSharedSecret(privateKey, publicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(got, want) {
+			t.Errorf("SharedSecretSelect(%d) = %x, want %x", i, got, want)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		privateKeys []ecdh25519.PrivateKey
+		publicKey   ecdh25519.PublicKey
+		index       int
+		wantErr     error
+	}{
+		{
+			name:        "with negative index",
+			privateKeys: privateKeys,
+			publicKey:   publicKey,
+			index:       -1,
+			wantErr:     ecdh25519.ErrBadKeyIndex,
+		},
+		{
+			name:        "with index out of range",
+			privateKeys: privateKeys,
+			publicKey:   publicKey,
+			index:       len(privateKeys),
+			wantErr:     ecdh25519.ErrBadKeyIndex,
+		},
+		{
+			name:        "with short private key",
+			privateKeys: []ecdh25519.PrivateKey{privateKeys[0], privateKeys[1][:16]},
+			publicKey:   publicKey,
+			index:       0,
+			wantErr:     ecdh25519.ErrBadPrivateKeyLength,
+		},
+		{
+			name:        "with short public key",
+			privateKeys: privateKeys,
+			publicKey:   publicKey[:16],
+			index:       0,
+			wantErr:     ecdh25519.ErrBadPublicKeyLength,
+		},
+		{
+			name:        "with low order point",
+			privateKeys: privateKeys,
+			publicKey:   make([]byte, ecdh25519.PublicKeySize),
+			index:       0,
+			wantErr:     ecdh25519.ErrLowOrderPoint,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ecdh25519.SharedSecretSelect(tt.privateKeys, tt.publicKey, tt.index); !errors.Is(err, tt.wantErr) {
+				t.Errorf("SharedSecretSelect() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
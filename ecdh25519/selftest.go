@@ -0,0 +1,80 @@
+package ecdh25519
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+var ErrSelfTestFailed = errors.New("ecdh25519: self-test failed")
+
+// test vectors from: https://www.rfc-editor.org/rfc/rfc7748#section-6.1
+const (
+	selfTestAlicePrivateKey = "77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a"
+	selfTestAlicePublicKey  = "8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a"
+	selfTestBobPrivateKey   = "5dab087e624a8a4b79e17f8b83800ee66f3bb1292618b6fd1c2f8b27ff88e0eb"
+	selfTestBobPublicKey    = "de9edb7d7b7dc1b4d35b61c2ece435373f8343c85b78674dadfc7e146f882b4f"
+	selfTestSharedSecret    = "4a5d9d5ba4ce2de1728e3bf480350f25e07e21c947d19e3376f09b3c1e161742"
+)
+
+// SelfTest checks the implementation against the test vectors from RFC 7748,
+// section 6.1: it derives Alice's and Bob's public keys and the shared secret
+// from both sides. Services can call it at startup and refuse to serve if it
+// returns an error, which wraps ErrSelfTestFailed.
+func SelfTest() error {
+	alicePrivateKey := PrivateKey(mustDecodeHex(selfTestAlicePrivateKey))
+	bobPrivateKey := PrivateKey(mustDecodeHex(selfTestBobPrivateKey))
+	defer alicePrivateKey.Zeroize()
+	defer bobPrivateKey.Zeroize()
+
+	alicePublicKey, err := alicePrivateKey.PublicKey()
+	if err != nil {
+		return fmt.Errorf("%w: alice public key: %w", ErrSelfTestFailed, err)
+	}
+
+	if !bytes.Equal(alicePublicKey, mustDecodeHex(selfTestAlicePublicKey)) {
+		return fmt.Errorf("%w: alice public key mismatch", ErrSelfTestFailed)
+	}
+
+	bobPublicKey, err := bobPrivateKey.PublicKey()
+	if err != nil {
+		return fmt.Errorf("%w: bob public key: %w", ErrSelfTestFailed, err)
+	}
+
+	if !bytes.Equal(bobPublicKey, mustDecodeHex(selfTestBobPublicKey)) {
+		return fmt.Errorf("%w: bob public key mismatch", ErrSelfTestFailed)
+	}
+
+	want := mustDecodeHex(selfTestSharedSecret)
+	for _, side := range []struct {
+		name       string
+		privateKey PrivateKey
+		publicKey  PublicKey
+	}{
+		{name: "alice", privateKey: alicePrivateKey, publicKey: bobPublicKey},
+		{name: "bob", privateKey: bobPrivateKey, publicKey: alicePublicKey},
+	} {
+		sharedSecret, err := GenerateSharedSecret(side.privateKey, side.publicKey)
+		if err != nil {
+			return fmt.Errorf("%w: %s shared secret: %w", ErrSelfTestFailed, side.name, err)
+		}
+
+		ok := bytes.Equal(sharedSecret, want)
+		sharedSecret.Zeroize()
+		if !ok {
+			return fmt.Errorf("%w: %s shared secret mismatch", ErrSelfTestFailed, side.name)
+		}
+	}
+
+	return nil
+}
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
@@ -0,0 +1,13 @@
+package ecdh25519_test
+
+import (
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestSelfTest(t *testing.T) {
+	if err := ecdh25519.SelfTest(); err != nil {
+		t.Errorf("SelfTest() error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,61 @@
+package ecdh25519
+
+import (
+	"crypto/ecdh"
+	"errors"
+	"fmt"
+)
+
+var ErrUnsupportedCurve = errors.New("ecdh25519: unsupported curve")
+
+func init() {
+	registerCapability("crypto/ecdh")
+}
+
+// ToStdlib returns the private key as a crypto/ecdh X25519 private key.
+func (p PrivateKey) ToStdlib() (*ecdh.PrivateKey, error) {
+	if l := len(p); l != PrivateKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	return ecdh.X25519().NewPrivateKey(p)
+}
+
+// FromStdlibPrivateKey returns the PrivateKey corresponding to a crypto/ecdh
+// X25519 private key.
+func FromStdlibPrivateKey(k *ecdh.PrivateKey) (PrivateKey, error) {
+	if k == nil || k.Curve() != ecdh.X25519() {
+		return nil, ErrUnsupportedCurve
+	}
+
+	b := k.Bytes()
+	if l := len(b); l != PrivateKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	return PrivateKey(b), nil
+}
+
+// ToStdlib returns the public key as a crypto/ecdh X25519 public key.
+func (p PublicKey) ToStdlib() (*ecdh.PublicKey, error) {
+	if l := len(p); l != PublicKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	return ecdh.X25519().NewPublicKey(p)
+}
+
+// FromStdlibPublicKey returns the PublicKey corresponding to a crypto/ecdh
+// X25519 public key.
+func FromStdlibPublicKey(k *ecdh.PublicKey) (PublicKey, error) {
+	if k == nil || k.Curve() != ecdh.X25519() {
+		return nil, ErrUnsupportedCurve
+	}
+
+	b := k.Bytes()
+	if l := len(b); l != PublicKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	return PublicKey(b), nil
+}
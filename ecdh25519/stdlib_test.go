@@ -0,0 +1,96 @@
+package ecdh25519_test
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestPrivateKey_ToStdlib(t *testing.T) {
+	alicePublicKey, alicePrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bobPublicKey, bobPrivateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stdAlicePrivateKey, err := alicePrivateKey.ToStdlib()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := stdAlicePrivateKey.PublicKey().Bytes(); !reflect.DeepEqual(got, []byte(alicePublicKey)) {
+		t.Errorf("PrivateKey.ToStdlib() public key = %v, want %v", got, alicePublicKey)
+	}
+
+	stdBobPublicKey, err := bobPublicKey.ToStdlib()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := stdAlicePrivateKey.ECDH(stdBobPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := ecdh25519.GenerateSharedSecret(bobPrivateKey, alicePublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want.Bytes()) {
+		t.Errorf("stdlib shared secret = %v, want %v", got, want)
+	}
+
+	privateKey, err := ecdh25519.FromStdlibPrivateKey(stdAlicePrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(privateKey, alicePrivateKey) {
+		t.Errorf("FromStdlibPrivateKey() = %v, want %v", privateKey, alicePrivateKey)
+	}
+
+	publicKey, err := ecdh25519.FromStdlibPublicKey(stdBobPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(publicKey, bobPublicKey) {
+		t.Errorf("FromStdlibPublicKey() = %v, want %v", publicKey, bobPublicKey)
+	}
+}
+
+func TestToStdlib_errors(t *testing.T) {
+	if _, err := ecdh25519.PrivateKey(make([]byte, 16)).ToStdlib(); !errors.Is(err, ecdh25519.ErrBadPrivateKeyLength) {
+		t.Errorf("PrivateKey.ToStdlib() error = %v, want %v", err, ecdh25519.ErrBadPrivateKeyLength)
+	}
+
+	if _, err := ecdh25519.PublicKey(make([]byte, 16)).ToStdlib(); !errors.Is(err, ecdh25519.ErrBadPublicKeyLength) {
+		t.Errorf("PublicKey.ToStdlib() error = %v, want %v", err, ecdh25519.ErrBadPublicKeyLength)
+	}
+
+	p256PrivateKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ecdh25519.FromStdlibPrivateKey(p256PrivateKey); !errors.Is(err, ecdh25519.ErrUnsupportedCurve) {
+		t.Errorf("FromStdlibPrivateKey() error = %v, want %v", err, ecdh25519.ErrUnsupportedCurve)
+	}
+
+	if _, err := ecdh25519.FromStdlibPublicKey(p256PrivateKey.PublicKey()); !errors.Is(err, ecdh25519.ErrUnsupportedCurve) {
+		t.Errorf("FromStdlibPublicKey() error = %v, want %v", err, ecdh25519.ErrUnsupportedCurve)
+	}
+
+	if _, err := ecdh25519.FromStdlibPublicKey(nil); !errors.Is(err, ecdh25519.ErrUnsupportedCurve) {
+		t.Errorf("FromStdlibPublicKey() error = %v, want %v", err, ecdh25519.ErrUnsupportedCurve)
+	}
+}
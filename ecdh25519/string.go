@@ -0,0 +1,43 @@
+package ecdh25519
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+)
+
+// String returns the hex encoding of the public key.
+func (p PublicKey) String() string {
+	return hex.EncodeToString(p)
+}
+
+// Format implements fmt.Formatter. The %x and %X verbs encode the raw key bytes,
+// as they would for a []byte, rather than the hex of String, and %#v prints the
+// bytes as Go syntax. Every other verb formats String.
+func (p PublicKey) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'x' || verb == 'X' || verb == 'v' && f.Flag('#'):
+		fmt.Fprintf(f, fmt.FormatString(f, verb), []byte(p))
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.String())
+	}
+}
+
+// String returns a fixed placeholder, so that private keys are not leaked by
+// accidentally formatting or logging them. Use the key bytes directly, or one
+// of the marshaling methods, when the encoding is actually needed.
+func (p PrivateKey) String() string {
+	return "ecdh25519.PrivateKey(redacted)"
+}
+
+// GoString is like String, for code that calls fmt.GoStringer directly.
+func (p PrivateKey) GoString() string {
+	return p.String()
+}
+
+// Format implements fmt.Formatter. It writes the placeholder of String for
+// every verb and flag, so that verbs such as %d or %x, which would otherwise
+// format the underlying bytes, cannot leak the key either.
+func (p PrivateKey) Format(f fmt.State, verb rune) {
+	io.WriteString(f, p.String())
+}
@@ -0,0 +1,86 @@
+package ecdh25519_test
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestPublicKey_String(t *testing.T) {
+	publicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := publicKey.String(), hex.EncodeToString(publicKey); got != want {
+		t.Errorf("PublicKey.String() = %v, want %v", got, want)
+	}
+
+	raw := []byte(publicKey)
+	for _, verb := range []string{"%x", "%X", "% x", "%v", "%s", "%q", "%#v", "%70s"} {
+		want := fmt.Sprintf(verb, hex.EncodeToString(raw))
+		if verb == "%x" || verb == "%X" || verb == "% x" || verb == "%#v" {
+			want = fmt.Sprintf(verb, raw)
+		}
+
+		if got := fmt.Sprintf(verb, publicKey); got != want {
+			t.Errorf("Sprintf(%q, publicKey) = %v, want %v", verb, got, want)
+		}
+	}
+}
+
+func TestPrivateKey_String(t *testing.T) {
+	_, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "ecdh25519.PrivateKey(redacted)"
+
+	verbs := []string{"%v", "%+v", "%#v", "%s", "%q", "%x", "%X", "%d", "%o", "%b"}
+
+	// leaks holds the key bytes as each verb would format a plain []byte,
+	// without the surrounding brackets and quotes.
+	var leaks []string
+	for _, verb := range verbs {
+		leak := fmt.Sprintf(verb, []byte(privateKey))
+		leak = strings.TrimPrefix(leak, "[]byte{")
+		leaks = append(leaks, strings.Trim(leak, "[]{}\""))
+	}
+
+	for _, verb := range verbs {
+		tests := []struct {
+			name string
+			got  string
+		}{
+			{name: "direct", got: fmt.Sprintf(verb, privateKey)},
+			{name: "nested", got: fmt.Sprintf(verb, struct{ Key ecdh25519.PrivateKey }{privateKey})},
+		}
+
+		for _, tt := range tests {
+			t.Run(verb+"/"+tt.name, func(t *testing.T) {
+				if !strings.Contains(tt.got, want) {
+					t.Errorf("Sprintf(%q) = %v, want it to contain %v", verb, tt.got, want)
+				}
+
+				for _, leak := range leaks {
+					if strings.Contains(tt.got, leak) {
+						t.Errorf("Sprintf(%q) = %v, leaks the key", verb, tt.got)
+					}
+				}
+			})
+		}
+
+		if got := fmt.Sprintf(verb, privateKey); got != want {
+			t.Errorf("Sprintf(%q, privateKey) = %v, want %v", verb, got, want)
+		}
+	}
+
+	if got := privateKey.String(); got != want {
+		t.Errorf("PrivateKey.String() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,38 @@
+package ecdh25519
+
+import "fmt"
+
+// ThreeParty orchestrates a three-party Diffie-Hellman exchange on top of
+// ScalarMult, where participants A, B and C are arranged in a ring and each one
+// sends to the next. Its zero value is ready to use.
+//
+// In Round1 every participant sends its public key to the next one. Every
+// participant then runs Round2 twice: first on the point received in Round1,
+// forwarding the result to the next participant, and then on the point received
+// in that second pass, which yields the common key abc * G.
+type ThreeParty struct{}
+
+// Round1 returns the point a participant sends to the next one, its public key.
+func (ThreeParty) Round1(privateKey PrivateKey) (PublicKey, error) {
+	return privateKey.PublicKey()
+}
+
+// Round2 multiplies a received point by the participant's private key. After
+// the first pass the result is a point to forward, as PublicKey(result); after
+// the second it is the common shared secret.
+func (ThreeParty) Round2(privateKey PrivateKey, received PublicKey) (SharedSecret, error) {
+	if l := len(privateKey); l != PrivateKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	if l := len(received); l != PublicKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	point, err := ScalarMult(privateKey, received)
+	if err != nil {
+		return nil, err
+	}
+
+	return point, nil
+}
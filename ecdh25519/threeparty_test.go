@@ -0,0 +1,65 @@
+package ecdh25519_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestThreeParty(t *testing.T) {
+	var tp ecdh25519.ThreeParty
+
+	privateKeys := make([]ecdh25519.PrivateKey, 3)
+	for i := range privateKeys {
+		_, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		privateKeys[i] = privateKey
+	}
+
+	// inbox[i] is the point participant i received from participant i-1.
+	inbox := make([]ecdh25519.PublicKey, 3)
+	for i, privateKey := range privateKeys {
+		publicKey, err := tp.Round1(privateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		inbox[(i+1)%3] = publicKey
+	}
+
+	forwarded := make([]ecdh25519.PublicKey, 3)
+	for i, privateKey := range privateKeys {
+		point, err := tp.Round2(privateKey, inbox[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		forwarded[(i+1)%3] = ecdh25519.PublicKey(point)
+	}
+
+	keys := make([]ecdh25519.SharedSecret, 3)
+	for i, privateKey := range privateKeys {
+		key, err := tp.Round2(privateKey, forwarded[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		keys[i] = key
+	}
+
+	if !reflect.DeepEqual(keys[0], keys[1]) || !reflect.DeepEqual(keys[1], keys[2]) {
+		t.Errorf("ThreeParty keys = %x, want all equal", keys)
+	}
+
+	for i := range keys {
+		if bytes.Equal(keys[i], forwarded[i]) || bytes.Equal(keys[i], inbox[i]) {
+			t.Errorf("ThreeParty key %d equals an exchanged point", i)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package ecdh25519
+
+import "time"
+
+// GenerateSharedSecretTimed is like GenerateSharedSecret, but it also returns the
+// wall-clock duration of the computation.
+//
+// It is a diagnostic API for timing-leak analysis, such as checking whether the
+// duration correlates with key bits. It is not meant for production hot paths.
+func GenerateSharedSecretTimed(privateKey PrivateKey, publicKey PublicKey) (SharedSecret, time.Duration, error) {
+	start := time.Now()
+	sharedSecret, err := GenerateSharedSecret(privateKey, publicKey)
+	elapsed := time.Since(start)
+
+	return sharedSecret, elapsed, err
+}
@@ -0,0 +1,39 @@
+package ecdh25519_test
+
+import (
+	"crypto/rand"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestGenerateSharedSecretTimed(t *testing.T) {
+	publicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, elapsed, err := ecdh25519.GenerateSharedSecretTimed(privateKey, publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := ecdh25519.GenerateSharedSecret(privateKey, publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GenerateSharedSecretTimed() = %v, want %v", got, want)
+	}
+
+	if elapsed <= 0 {
+		t.Errorf("GenerateSharedSecretTimed() duration = %v, want > 0", elapsed)
+	}
+}
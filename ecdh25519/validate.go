@@ -0,0 +1,142 @@
+package ecdh25519
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrWeakPublicKey         = errors.New("ecdh25519: weak public key")
+	ErrNonCanonicalPublicKey = errors.New("ecdh25519: non-canonical public key")
+)
+
+// weakPublicKeys are the encodings of the Curve25519 points of small order,
+// including their non-canonical forms: with the top bit set or not reduced modulo p.
+// Any scalar multiplied by one of them yields a point of small order, which
+// makes the shared secret predictable.
+var weakPublicKeys = decodeHexKeys(
+	// 0
+	"0000000000000000000000000000000000000000000000000000000000000000",
+	// 1
+	"0100000000000000000000000000000000000000000000000000000000000000",
+	// point of order 8
+	"e0eb7a7c3b41b8ae1656e3faf19fc46ada098deb9c32b1fd866205165f49b800",
+	// point of order 8
+	"5f9c95bca3508c24b1d0b1559c83ef5b04445cc4581c8e86d8224eddd09f1157",
+	// p - 1
+	"ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+	// p, non-canonical 0
+	"edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+	// p + 1, non-canonical 1
+	"eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+	// 0 with the top bit set
+	"0000000000000000000000000000000000000000000000000000000000000080",
+	// 1 with the top bit set
+	"0100000000000000000000000000000000000000000000000000000000000080",
+	// point of order 8 with the top bit set
+	"e0eb7a7c3b41b8ae1656e3faf19fc46ada098deb9c32b1fd866205165f49b880",
+	// point of order 8 with the top bit set
+	"5f9c95bca3508c24b1d0b1559c83ef5b04445cc4581c8e86d8224eddd09f11d7",
+	// p - 1 with the top bit set
+	"ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+	// p with the top bit set
+	"edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+	// p + 1 with the top bit set
+	"eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+)
+
+// WeakPublicKeys lists the public keys rejected by Validate, for auditing and
+// cross-checking against other deny lists. It must be treated as read-only;
+// Validate uses its own copy, so modifying it does not change what Validate
+// rejects.
+var WeakPublicKeys = func() []PublicKey {
+	keys := make([]PublicKey, len(weakPublicKeys))
+	for i, k := range weakPublicKeys {
+		keys[i] = append(PublicKey(nil), k...)
+	}
+
+	return keys
+}()
+
+// Validate checks that the public key has the right length and is not one of
+// the low-order points of Curve25519, so that bad input can be rejected before
+// computing a shared secret. It returns ErrBadPublicKeyLength or
+// ErrWeakPublicKey respectively.
+func (p PublicKey) Validate() error {
+	if l := len(p); l != PublicKeySize {
+		return fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	weak := 0
+	for _, k := range weakPublicKeys {
+		weak |= subtle.ConstantTimeCompare(p, k)
+	}
+
+	if weak == 1 {
+		return ErrWeakPublicKey
+	}
+
+	return nil
+}
+
+// ValidateCanonical is like Validate, but it also returns
+// ErrNonCanonicalPublicKey if the key is not the canonical encoding of its
+// u-coordinate: if bit 255 is set, which X25519 ignores, or if the value is
+// not reduced modulo p = 2^255 - 19. Each of those keys computes the same
+// shared secrets as a canonical one, so protocols that hash or compare raw key
+// bytes should reject them.
+func (p PublicKey) ValidateCanonical() error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	if p[31]&0x80 != 0 || !reducedModP(p) {
+		return ErrNonCanonicalPublicKey
+	}
+
+	return nil
+}
+
+// GenerateSharedSecretStrict is like GenerateSharedSecret, but it first checks
+// publicKey with ValidateCanonical, for protocols that bind transcripts to raw
+// key bytes.
+func GenerateSharedSecretStrict(privateKey PrivateKey, publicKey PublicKey) (SharedSecret, error) {
+	if err := publicKey.ValidateCanonical(); err != nil {
+		return nil, err
+	}
+
+	return GenerateSharedSecret(privateKey, publicKey)
+}
+
+// reducedModP reports whether the 255-bit little-endian value of u, ignoring
+// bit 255, is below p = 2^255 - 19. The only values at or above p are those
+// with every bit from 5 to 254 set and a low byte of at least 0xed.
+func reducedModP(u []byte) bool {
+	if u[31]&0x7f != 0x7f || u[0] < 0xed {
+		return true
+	}
+
+	for _, b := range u[1:31] {
+		if b != 0xff {
+			return true
+		}
+	}
+
+	return false
+}
+
+func decodeHexKeys(s ...string) []PublicKey {
+	keys := make([]PublicKey, len(s))
+	for i, v := range s {
+		b, err := hex.DecodeString(v)
+		if err != nil || len(b) != PublicKeySize {
+			panic("ecdh25519: bad hex key: " + v)
+		}
+
+		keys[i] = b
+	}
+
+	return keys
+}
@@ -0,0 +1,182 @@
+package ecdh25519_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+// low-order points from: https://cr.yp.to/ecdh.html#validate
+
+var lowOrderPoints = []string{
+	"0000000000000000000000000000000000000000000000000000000000000000",
+	"0100000000000000000000000000000000000000000000000000000000000000",
+	"e0eb7a7c3b41b8ae1656e3faf19fc46ada098deb9c32b1fd866205165f49b800",
+	"5f9c95bca3508c24b1d0b1559c83ef5b04445cc4581c8e86d8224eddd09f1157",
+	"ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+	"edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+	"eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+	"0000000000000000000000000000000000000000000000000000000000000080",
+	"0100000000000000000000000000000000000000000000000000000000000080",
+	"e0eb7a7c3b41b8ae1656e3faf19fc46ada098deb9c32b1fd866205165f49b880",
+	"5f9c95bca3508c24b1d0b1559c83ef5b04445cc4581c8e86d8224eddd09f11d7",
+	"ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+	"edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+	"eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+}
+
+func TestPublicKey_Validate(t *testing.T) {
+	_, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, point := range lowOrderPoints {
+		t.Run(point, func(t *testing.T) {
+			publicKey, err := hex.DecodeString(point)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := ecdh25519.PublicKey(publicKey).Validate(); !errors.Is(err, ecdh25519.ErrWeakPublicKey) {
+				t.Errorf("PublicKey.Validate() error = %v, want %v", err, ecdh25519.ErrWeakPublicKey)
+			}
+
+			if _, err := ecdh25519.GenerateSharedSecret(privateKey, publicKey); !errors.Is(err, ecdh25519.ErrLowOrderPoint) {
+				t.Errorf("GenerateSharedSecret() error = %v, want %v", err, ecdh25519.ErrLowOrderPoint)
+			}
+		})
+	}
+
+	publicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := publicKey.Validate(); err != nil {
+		t.Errorf("PublicKey.Validate() error = %v, want nil", err)
+	}
+
+	if err := publicKey[:16].Validate(); !errors.Is(err, ecdh25519.ErrBadPublicKeyLength) {
+		t.Errorf("PublicKey.Validate() error = %v, want %v", err, ecdh25519.ErrBadPublicKeyLength)
+	}
+}
+
+func TestPublicKey_ValidateCanonical(t *testing.T) {
+	hexKey := func(s string) ecdh25519.PublicKey {
+		t.Helper()
+
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return b
+	}
+
+	tests := []struct {
+		name      string
+		publicKey ecdh25519.PublicKey
+		wantErr   error
+	}{
+		{
+			name:      "with high bit clear",
+			publicKey: hexKey("de9edb7d7b7dc1b4d35b61c2ece435373f8343c85b78674dadfc7e146f882b4f"),
+		},
+		{
+			name:      "with high bit set",
+			publicKey: hexKey("de9edb7d7b7dc1b4d35b61c2ece435373f8343c85b78674dadfc7e146f882bcf"),
+			wantErr:   ecdh25519.ErrNonCanonicalPublicKey,
+		},
+		{
+			name:      "with largest canonical value",
+			publicKey: hexKey("ebffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f"),
+		},
+		{
+			name:      "with p + 2",
+			publicKey: hexKey("efffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f"),
+			wantErr:   ecdh25519.ErrNonCanonicalPublicKey,
+		},
+		{
+			name:      "with low order point",
+			publicKey: hexKey(lowOrderPoints[2]),
+			wantErr:   ecdh25519.ErrWeakPublicKey,
+		},
+		{
+			name:      "with short key",
+			publicKey: make([]byte, 16),
+			wantErr:   ecdh25519.ErrBadPublicKeyLength,
+		},
+	}
+
+	_, privateKey, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.publicKey.ValidateCanonical(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("PublicKey.ValidateCanonical() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if _, err := ecdh25519.GenerateSharedSecretStrict(privateKey, tt.publicKey); !errors.Is(err, tt.wantErr) {
+				t.Errorf("GenerateSharedSecretStrict() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// The non-canonical encoding computes the same secret, which is what makes
+	// it a malleability risk.
+	canonical, err := ecdh25519.GenerateSharedSecret(privateKey, tests[0].publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nonCanonical, err := ecdh25519.GenerateSharedSecret(privateKey, tests[1].publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(canonical, nonCanonical) {
+		t.Errorf("GenerateSharedSecret() differs for the high bit, want equal secrets")
+	}
+}
+
+func TestWeakPublicKeys(t *testing.T) {
+	if got, want := len(ecdh25519.WeakPublicKeys), len(lowOrderPoints); got != want {
+		t.Errorf("len(WeakPublicKeys) = %d, want %d", got, want)
+	}
+
+	for _, point := range lowOrderPoints {
+		found := false
+		for _, publicKey := range ecdh25519.WeakPublicKeys {
+			if hex.EncodeToString(publicKey) == point {
+				found = true
+			}
+		}
+
+		if !found {
+			t.Errorf("WeakPublicKeys does not contain %v", point)
+		}
+	}
+
+	for _, publicKey := range ecdh25519.WeakPublicKeys {
+		if err := publicKey.Validate(); !errors.Is(err, ecdh25519.ErrWeakPublicKey) {
+			t.Errorf("PublicKey.Validate() for %v error = %v, want %v", publicKey, err, ecdh25519.ErrWeakPublicKey)
+		}
+	}
+
+	weak := ecdh25519.WeakPublicKeys[0]
+	original := weak[0]
+	weak[0] ^= 1
+	defer func() { weak[0] = original }()
+
+	if err := ecdh25519.PublicKey(make([]byte, ecdh25519.PublicKeySize)).Validate(); !errors.Is(err, ecdh25519.ErrWeakPublicKey) {
+		t.Errorf("modifying WeakPublicKeys changed Validate(): error = %v, want %v", err, ecdh25519.ErrWeakPublicKey)
+	}
+}
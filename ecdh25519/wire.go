@@ -0,0 +1,30 @@
+package ecdh25519
+
+import (
+	"fmt"
+	"io"
+)
+
+// WriteTo implements io.WriterTo. It writes the PublicKeySize bytes of the key
+// to w, with no framing: the size is fixed, so the peer reads it back with
+// ReadPublicKeyFrom.
+func (p PublicKey) WriteTo(w io.Writer) (int64, error) {
+	if l := len(p); l != PublicKeySize {
+		return 0, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	n, err := w.Write(p)
+	return int64(n), err
+}
+
+// ReadPublicKeyFrom reads exactly PublicKeySize bytes from r, as written by
+// PublicKey.WriteTo. A connection that closes early yields io.ErrUnexpectedEOF,
+// or io.EOF if no bytes were read at all.
+func ReadPublicKeyFrom(r io.Reader) (PublicKey, error) {
+	publicKey := make(PublicKey, PublicKeySize)
+	if _, err := io.ReadFull(r, publicKey); err != nil {
+		return nil, err
+	}
+
+	return publicKey, nil
+}
@@ -0,0 +1,76 @@
+package ecdh25519_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+	"testing/iotest"
+
+	"github.com/adnsio/ecdh/ecdh25519"
+)
+
+func TestPublicKey_WriteTo(t *testing.T) {
+	publicKey, _, err := ecdh25519.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	go func() {
+		if _, err := publicKey.WriteTo(client); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	// Hand the bytes over one at a time, as a slow connection would.
+	got, err := ecdh25519.ReadPublicKeyFrom(iotest.OneByteReader(server))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, publicKey) {
+		t.Errorf("ReadPublicKeyFrom() = %v, want %v", got, publicKey)
+	}
+
+	if _, err := publicKey[:16].WriteTo(io.Discard); !errors.Is(err, ecdh25519.ErrBadPublicKeyLength) {
+		t.Errorf("PublicKey.WriteTo() error = %v, want %v", err, ecdh25519.ErrBadPublicKeyLength)
+	}
+}
+
+func TestReadPublicKeyFrom(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       io.Reader
+		wantErr error
+	}{
+		{
+			name: "with exact key",
+			r:    bytes.NewReader(make([]byte, ecdh25519.PublicKeySize)),
+		},
+		{
+			name:    "with short key",
+			r:       bytes.NewReader(make([]byte, 16)),
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name:    "with closed connection",
+			r:       bytes.NewReader(nil),
+			wantErr: io.EOF,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ecdh25519.ReadPublicKeyFrom(tt.r); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ReadPublicKeyFrom() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
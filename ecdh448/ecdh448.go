@@ -0,0 +1,108 @@
+// Package ecdh448 implements the curve448 diffie-hellman protocol.
+// See https://www.ietf.org/rfc/rfc7748.html.
+package ecdh448
+
+import (
+	cryptorand "crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cloudflare/circl/dh/x448"
+)
+
+const (
+	// PublicKeySize is the size, in bytes, of public keys as used in this package.
+	PublicKeySize = x448.Size
+	// PrivateKeySize is the size, in bytes, of private keys as used in this package.
+	PrivateKeySize = x448.Size
+)
+
+var (
+	ErrBadPrivateKeyLength = errors.New("ecdh448: bad private key length")
+	ErrBadPublicKeyLength  = errors.New("ecdh448: bad public key length")
+	ErrLowOrderPoint       = errors.New("ecdh448: low order point")
+)
+
+// PublicKey is the type of ecdh448 public keys.
+type PublicKey []byte
+
+// PrivateKey is the type of ecdh448 private keys.
+type PrivateKey []byte
+
+// PublicKey returns the PublicKey corresponding to the PrivateKey.
+func (p PrivateKey) PublicKey() (PublicKey, error) {
+	if l := len(p); l != PrivateKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	var scalar, publicKey x448.Key
+	copy(scalar[:], p)
+
+	x448.KeyGen(&publicKey, &scalar)
+	zeroize(scalar[:])
+
+	return publicKey[:], nil
+}
+
+// GenerateKeyPair generates a public/private key pair using entropy from rand.
+// If rand is nil, crypto/rand.Reader will be used.
+func GenerateKeyPair(rand io.Reader) (PublicKey, PrivateKey, error) {
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+
+	privateKey := make(PrivateKey, PrivateKeySize)
+	if _, err := io.ReadFull(rand, privateKey); err != nil {
+		return nil, nil, err
+	}
+
+	clamp(privateKey)
+
+	publicKey, err := privateKey.PublicKey()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return publicKey, privateKey, nil
+}
+
+// GenerateSharedSecret generates a shared secret by using someone else's public key.
+// It returns ErrLowOrderPoint if publicKey is a low-order point, which would
+// force an all-zero shared secret.
+func GenerateSharedSecret(privateKey PrivateKey, publicKey PublicKey) ([]byte, error) {
+	if l := len(privateKey); l != PrivateKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	if l := len(publicKey); l != PublicKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	var scalar, point, sharedSecret x448.Key
+	copy(scalar[:], privateKey)
+	copy(point[:], publicKey)
+
+	ok := x448.Shared(&sharedSecret, &scalar, &point)
+	zeroize(scalar[:])
+
+	if !ok {
+		return nil, ErrLowOrderPoint
+	}
+
+	return sharedSecret[:], nil
+}
+
+// clamp applies the X448 scalar clamping from RFC 7748, section 5: the two
+// least significant bits are cleared and the most significant bit is set.
+func clamp(k []byte) {
+	k[0] &= 252
+	k[55] |= 128
+}
+
+// zeroize overwrites b with zeros.
+func zeroize(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
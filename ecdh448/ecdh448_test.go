@@ -0,0 +1,215 @@
+package ecdh448_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdh448"
+)
+
+// test vectors from: https://www.ietf.org/rfc/rfc7748.html#section-6.2
+
+const (
+	alicePrivateKeyHex = "9a8f4925d1519f5775cf46b04b5800d4ee9ee8bae8bc5565d498c28dd9c9baf574a9419744897391006382a6f127ab1d9ac2d8c0a598726b"
+	alicePublicKeyHex  = "9b08f7cc31b7e3e67d22d5aea121074a273bd2b83de09c63faa73d2c22c5d9bbc836647241d953d40c5b12da88120d53177f80e532c41fa0"
+	bobPrivateKeyHex   = "1c306a7ac2a0e2e0990b294470cba339e6453772b075811d8fad0d1d6927c120bb5ee8972b0d3e21374c9c921b09d1b0366f10b65173992d"
+	bobPublicKeyHex    = "3eb7a829b0cd20f5bcfc0b599b6feccf6da4627107bdb0d4f345b43027d8b972fc3e34fb4232a13ca706dcb57aec3dae07bdc1c67bf33609"
+	sharedSecretHex    = "07fff4181ac6cc95ec1c16a94a0f74d12da232ce40a77552281d282bb60c0b56fd2464c335543936521c24403085d59a449a5037514a879d"
+)
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+func TestGenerateKeyPair(t *testing.T) {
+	type args struct {
+		rand io.Reader
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "with crypto rand",
+			args: args{
+				rand: rand.Reader,
+			},
+		},
+		{
+			name: "with short reader",
+			args: args{
+				rand: bytes.NewReader(make([]byte, 16)),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, privateKey, err := ecdh448.GenerateKeyPair(tt.args.rand)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GenerateKeyPair() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if privateKey[0]&3 != 0 || privateKey[55]&128 == 0 {
+				t.Errorf("GenerateKeyPair() private key %x is not clamped", privateKey)
+			}
+		})
+	}
+}
+
+func TestGenerateSharedSecret(t *testing.T) {
+	type args struct {
+		privateKey ecdh448.PrivateKey
+		publicKey  ecdh448.PublicKey
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    []byte
+		wantErr error
+	}{
+		{
+			name: "with alice secret and bob public",
+			args: args{
+				privateKey: mustDecodeHex(t, alicePrivateKeyHex),
+				publicKey:  mustDecodeHex(t, bobPublicKeyHex),
+			},
+			want: mustDecodeHex(t, sharedSecretHex),
+		},
+		{
+			name: "with bob secret and alice public",
+			args: args{
+				privateKey: mustDecodeHex(t, bobPrivateKeyHex),
+				publicKey:  mustDecodeHex(t, alicePublicKeyHex),
+			},
+			want: mustDecodeHex(t, sharedSecretHex),
+		},
+		{
+			name: "with zero public key",
+			args: args{
+				privateKey: mustDecodeHex(t, alicePrivateKeyHex),
+				publicKey:  make([]byte, ecdh448.PublicKeySize),
+			},
+			wantErr: ecdh448.ErrLowOrderPoint,
+		},
+		{
+			name: "with short private key",
+			args: args{
+				privateKey: make([]byte, 32),
+				publicKey:  mustDecodeHex(t, bobPublicKeyHex),
+			},
+			wantErr: ecdh448.ErrBadPrivateKeyLength,
+		},
+		{
+			name: "with short public key",
+			args: args{
+				privateKey: mustDecodeHex(t, alicePrivateKeyHex),
+				publicKey:  make([]byte, 32),
+			},
+			wantErr: ecdh448.ErrBadPublicKeyLength,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ecdh448.GenerateSharedSecret(tt.args.privateKey, tt.args.publicKey)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GenerateSharedSecret() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GenerateSharedSecret() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrivateKey_PublicKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       ecdh448.PrivateKey
+		want    ecdh448.PublicKey
+		wantErr bool
+	}{
+		{
+			name: "alice public",
+			p:    mustDecodeHex(t, alicePrivateKeyHex),
+			want: mustDecodeHex(t, alicePublicKeyHex),
+		},
+		{
+			name: "bob public",
+			p:    mustDecodeHex(t, bobPrivateKeyHex),
+			want: mustDecodeHex(t, bobPublicKeyHex),
+		},
+		{
+			name:    "short private key",
+			p:       make([]byte, 32),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.p.PublicKey()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PrivateKey.PublicKey() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PrivateKey.PublicKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func ExampleGenerateKeyPair() {
+	alicePublicKey, alicePrivateKey, err := ecdh448.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	bobPublicKey, bobPrivateKey, err := ecdh448.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	aliceSharedSecret, err := ecdh448.GenerateSharedSecret(alicePrivateKey, bobPublicKey)
+	if err != nil {
+		panic(err)
+	}
+
+	bobSharedSecret, err := ecdh448.GenerateSharedSecret(bobPrivateKey, alicePublicKey)
+	if err != nil {
+		panic(err)
+	}
+
+	if bytes.Equal(aliceSharedSecret, bobSharedSecret) {
+		fmt.Printf("shared secrets are equal")
+	}
+
+	// Output: shared secrets are equal
+}
@@ -0,0 +1,72 @@
+package ecdh_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh"
+	"github.com/adnsio/ecdh/ecdh25519"
+	"github.com/adnsio/ecdh/ecdh448"
+	"github.com/adnsio/ecdh/ecdhp256"
+)
+
+func TestScheme(t *testing.T) {
+	schemes := []ecdh.Scheme{
+		ecdh25519.Scheme,
+		ecdh448.Scheme,
+		ecdhp256.Scheme,
+	}
+
+	for _, scheme := range schemes {
+		t.Run(scheme.Name(), func(t *testing.T) {
+			alicePublicKey, alicePrivateKey, err := scheme.GenerateKeyPair(rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			bobPublicKey, bobPrivateKey, err := scheme.GenerateKeyPair(rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			aliceSharedSecret, err := scheme.SharedSecret(alicePrivateKey, bobPublicKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			bobSharedSecret, err := scheme.SharedSecret(bobPrivateKey, alicePublicKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(aliceSharedSecret, bobSharedSecret) {
+				t.Errorf("SharedSecret() = %v and %v, want equal secrets", aliceSharedSecret, bobSharedSecret)
+			}
+
+			if _, err := scheme.SharedSecret(alicePrivateKey, bobPublicKey[:16]); err == nil {
+				t.Errorf("SharedSecret() with short public key error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestSchemes(t *testing.T) {
+	got := ecdh.Schemes()
+	want := []string{"p256", "x25519", "x448"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Schemes() = %v, want %v", got, want)
+	}
+}
+
+func TestRegister_duplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Register() with a registered name did not panic")
+		}
+	}()
+
+	ecdh.Register(ecdh25519.Scheme)
+}
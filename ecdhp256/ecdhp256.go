@@ -0,0 +1,108 @@
+// Package ecdhp256 implements the NIST P-256 elliptic curve diffie-hellman protocol.
+// See https://www.secg.org/sec1-v2.pdf and https://www.ietf.org/rfc/rfc5903.html.
+package ecdhp256
+
+import (
+	"crypto/ecdh"
+	cryptorand "crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// PublicKeySize is the size, in bytes, of public keys as used in this package.
+	// Public keys are uncompressed SEC 1 points.
+	PublicKeySize = 65
+	// PrivateKeySize is the size, in bytes, of private keys as used in this package.
+	PrivateKeySize = 32
+
+	// scalarAttempts is how many scalars GenerateKeyPair reads from rand before
+	// giving up. A working source yields an invalid scalar with probability
+	// about 2^-32, so repeated failures mean the source is broken.
+	scalarAttempts = 3
+)
+
+var (
+	ErrBadPrivateKeyLength = errors.New("ecdhp256: bad private key length")
+	ErrBadPublicKeyLength  = errors.New("ecdhp256: bad public key length")
+	ErrNoValidScalar       = errors.New("ecdhp256: no valid scalar")
+)
+
+// PublicKey is the type of ecdhp256 public keys.
+type PublicKey []byte
+
+// PrivateKey is the type of ecdhp256 private keys.
+type PrivateKey []byte
+
+// PublicKey returns the PublicKey corresponding to the PrivateKey.
+func (p PrivateKey) PublicKey() (PublicKey, error) {
+	if l := len(p); l != PrivateKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	privateKey, err := ecdh.P256().NewPrivateKey(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return privateKey.PublicKey().Bytes(), nil
+}
+
+// GenerateKeyPair generates a public/private key pair using entropy from rand.
+// If rand is nil, crypto/rand.Reader will be used.
+//
+// Scalars that are zero or not below the group order are rejected and read
+// again. If rand keeps producing them, as a source stuck on zeros would, it
+// returns ErrNoValidScalar.
+func GenerateKeyPair(rand io.Reader) (PublicKey, PrivateKey, error) {
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+
+	privateKey := make(PrivateKey, PrivateKeySize)
+	for i := 0; ; i++ {
+		if i == scalarAttempts {
+			return nil, nil, fmt.Errorf("%w after %d attempts", ErrNoValidScalar, scalarAttempts)
+		}
+
+		if _, err := io.ReadFull(rand, privateKey); err != nil {
+			return nil, nil, err
+		}
+
+		if _, err := ecdh.P256().NewPrivateKey(privateKey); err == nil {
+			break
+		}
+	}
+
+	publicKey, err := privateKey.PublicKey()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return publicKey, privateKey, nil
+}
+
+// GenerateSharedSecret generates a shared secret by using someone else's public key.
+// The shared secret is the X coordinate of the shared point, as defined by SEC 1.
+func GenerateSharedSecret(privateKey PrivateKey, publicKey PublicKey) ([]byte, error) {
+	if l := len(privateKey); l != PrivateKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPrivateKeyLength, l)
+	}
+
+	if l := len(publicKey); l != PublicKeySize {
+		return nil, fmt.Errorf("%w: %d", ErrBadPublicKeyLength, l)
+	}
+
+	k, err := ecdh.P256().NewPrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	peer, err := ecdh.P256().NewPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return k.ECDH(peer)
+}
@@ -0,0 +1,219 @@
+package ecdhp256_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/adnsio/ecdh/ecdhp256"
+)
+
+// test vectors from: https://www.ietf.org/rfc/rfc5903.html#section-8.1
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+
+	return len(p), nil
+}
+
+func TestGenerateKeyPair(t *testing.T) {
+	type args struct {
+		rand io.Reader
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "with crypto rand",
+			args: args{
+				rand: rand.Reader,
+			},
+		},
+		{
+			name: "with short reader",
+			args: args{
+				rand: bytes.NewReader(make([]byte, 16)),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ecdhp256.GenerateKeyPair(tt.args.rand)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GenerateKeyPair() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+		})
+	}
+
+	if _, _, err := ecdhp256.GenerateKeyPair(zeroReader{}); !errors.Is(err, ecdhp256.ErrNoValidScalar) {
+		t.Errorf("GenerateKeyPair() with zero reader error = %v, want %v", err, ecdhp256.ErrNoValidScalar)
+	}
+}
+
+func TestGenerateSharedSecret(t *testing.T) {
+	type args struct {
+		privateKey ecdhp256.PrivateKey
+		publicKey  ecdhp256.PublicKey
+	}
+
+	alicePrivateKey := mustDecodeHex(t, "c88f01f510d9ac3f70a292daa2316de544e9aab8afe84049c62a9c57862d1433")
+	alicePublicKey := mustDecodeHex(t, "04"+
+		"dad0b65394221cf9b051e1feca5787d098dfe637fc90b9ef945d0c3772581180"+
+		"5271a0461cdb8252d61f1c456fa3e59ab1f45b33accf5f58389e0577b8990bb3")
+	bobPrivateKey := mustDecodeHex(t, "c6ef9c5d78ae012a011164acb397ce2088685d8f06bf9be0b283ab46476bee53")
+	bobPublicKey := mustDecodeHex(t, "04"+
+		"d12dfb5289c8d4f81208b70270398c342296970a0bccb74c736fc7554494bf63"+
+		"56fbf3ca366cc23e8157854c13c58d6aac23f046ada30f8353e74f33039872ab")
+	sharedSecret := mustDecodeHex(t, "d6840f6b42f6edafd13116e0e12565202fef8e9ece7dce03812464d04b9442de")
+
+	tests := []struct {
+		name    string
+		args    args
+		want    []byte
+		wantErr error
+	}{
+		{
+			name: "with alice secret and bob public",
+			args: args{
+				privateKey: alicePrivateKey,
+				publicKey:  bobPublicKey,
+			},
+			want: sharedSecret,
+		},
+		{
+			name: "with bob secret and alice public",
+			args: args{
+				privateKey: bobPrivateKey,
+				publicKey:  alicePublicKey,
+			},
+			want: sharedSecret,
+		},
+		{
+			name: "with short private key",
+			args: args{
+				privateKey: alicePrivateKey[:16],
+				publicKey:  bobPublicKey,
+			},
+			wantErr: ecdhp256.ErrBadPrivateKeyLength,
+		},
+		{
+			name: "with short public key",
+			args: args{
+				privateKey: alicePrivateKey,
+				publicKey:  bobPublicKey[:33],
+			},
+			wantErr: ecdhp256.ErrBadPublicKeyLength,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ecdhp256.GenerateSharedSecret(tt.args.privateKey, tt.args.publicKey)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GenerateSharedSecret() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GenerateSharedSecret() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrivateKey_PublicKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       ecdhp256.PrivateKey
+		want    ecdhp256.PublicKey
+		wantErr bool
+	}{
+		{
+			name: "alice public",
+			p:    mustDecodeHex(t, "c88f01f510d9ac3f70a292daa2316de544e9aab8afe84049c62a9c57862d1433"),
+			want: mustDecodeHex(t, "04"+
+				"dad0b65394221cf9b051e1feca5787d098dfe637fc90b9ef945d0c3772581180"+
+				"5271a0461cdb8252d61f1c456fa3e59ab1f45b33accf5f58389e0577b8990bb3"),
+		},
+		{
+			name: "bob public",
+			p:    mustDecodeHex(t, "c6ef9c5d78ae012a011164acb397ce2088685d8f06bf9be0b283ab46476bee53"),
+			want: mustDecodeHex(t, "04"+
+				"d12dfb5289c8d4f81208b70270398c342296970a0bccb74c736fc7554494bf63"+
+				"56fbf3ca366cc23e8157854c13c58d6aac23f046ada30f8353e74f33039872ab"),
+		},
+		{
+			name:    "zero private key",
+			p:       make([]byte, ecdhp256.PrivateKeySize),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.p.PublicKey()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PrivateKey.PublicKey() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PrivateKey.PublicKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func ExampleGenerateKeyPair() {
+	alicePublicKey, alicePrivateKey, err := ecdhp256.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	bobPublicKey, bobPrivateKey, err := ecdhp256.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	aliceSharedSecret, err := ecdhp256.GenerateSharedSecret(alicePrivateKey, bobPublicKey)
+	if err != nil {
+		panic(err)
+	}
+
+	bobSharedSecret, err := ecdhp256.GenerateSharedSecret(bobPrivateKey, alicePublicKey)
+	if err != nil {
+		panic(err)
+	}
+
+	if bytes.Equal(aliceSharedSecret, bobSharedSecret) {
+		fmt.Printf("shared secrets are equal")
+	}
+
+	// Output: shared secrets are equal
+}
@@ -1,5 +1,11 @@
 module github.com/adnsio/ecdh
 
-go 1.17
+go 1.20
 
-require golang.org/x/crypto v0.0.0-20210921155107-089bfa567519
+require (
+	filippo.io/edwards25519 v1.1.0
+	github.com/cloudflare/circl v1.3.7
+	golang.org/x/crypto v0.17.0
+)
+
+require golang.org/x/sys v0.15.0 // indirect